@@ -0,0 +1,161 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package arch provides a normalised machine architecture, along with
+// conversions to and from the names used by the kernel, dpkg, rpm and Go.
+package arch
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Arch is a machine architecture, named as Juju names it.
+type Arch string
+
+const (
+	AMD64   Arch = "amd64"
+	ARM64   Arch = "arm64"
+	PPC64EL Arch = "ppc64el"
+	S390X   Arch = "s390x"
+	RISCV64 Arch = "riscv64"
+	I386    Arch = "i386"
+	ARMHF   Arch = "armhf"
+)
+
+// names holds the name of each architecture in the other naming schemes.
+type names struct {
+	kernel string
+	dpkg   string
+	rpm    string
+	goarch string
+	bits   int
+}
+
+var archNames = map[Arch]names{
+	AMD64:   {kernel: "x86_64", dpkg: "amd64", rpm: "x86_64", goarch: "amd64", bits: 64},
+	ARM64:   {kernel: "aarch64", dpkg: "arm64", rpm: "aarch64", goarch: "arm64", bits: 64},
+	PPC64EL: {kernel: "ppc64le", dpkg: "ppc64el", rpm: "ppc64le", goarch: "ppc64le", bits: 64},
+	S390X:   {kernel: "s390x", dpkg: "s390x", rpm: "s390x", goarch: "s390x", bits: 64},
+	RISCV64: {kernel: "riscv64", dpkg: "riscv64", rpm: "riscv64", goarch: "riscv64", bits: 64},
+	I386:    {kernel: "i686", dpkg: "i386", rpm: "i686", goarch: "386", bits: 32},
+	ARMHF:   {kernel: "armv7l", dpkg: "armhf", rpm: "armv7hl", goarch: "arm", bits: 32},
+}
+
+// kernelAliases holds the other machine names reported by uname for each
+// architecture.
+var kernelAliases = map[string]Arch{
+	"amd64":   AMD64,
+	"x64":     AMD64,
+	"arm64":   ARM64,
+	"aarch64": ARM64,
+	"i386":    I386,
+	"i486":    I386,
+	"i586":    I386,
+	"i686":    I386,
+	"x86":     I386,
+	"armv7l":  ARMHF,
+	"armv7hl": ARMHF,
+	"armv8l":  ARMHF,
+}
+
+// AllArches returns every supported architecture.
+func AllArches() []Arch {
+	return []Arch{AMD64, ARM64, PPC64EL, S390X, RISCV64, I386, ARMHF}
+}
+
+func (a Arch) String() string {
+	return string(a)
+}
+
+// IsValid reports whether a is a supported architecture.
+func (a Arch) IsValid() bool {
+	_, ok := archNames[a]
+	return ok
+}
+
+// Bits returns the word size of the architecture, or 0 if it is not
+// supported.
+func (a Arch) Bits() int {
+	return archNames[a].bits
+}
+
+// Kernel returns the machine name the Linux kernel reports for a, as
+// returned by uname -m.
+func (a Arch) Kernel() string {
+	return archNames[a].kernel
+}
+
+// Dpkg returns the Debian architecture name of a.
+func (a Arch) Dpkg() string {
+	return archNames[a].dpkg
+}
+
+// RPM returns the RPM architecture name of a.
+func (a Arch) RPM() string {
+	return archNames[a].rpm
+}
+
+// GOARCH returns the Go architecture name of a.
+func (a Arch) GOARCH() string {
+	return archNames[a].goarch
+}
+
+// ParseKernel returns the architecture of a machine name as reported by
+// uname -m.
+func ParseKernel(name string) (Arch, error) {
+	return parse(name, func(n names) string { return n.kernel }, kernelAliases)
+}
+
+// ParseDpkg returns the architecture with the given Debian name.
+func ParseDpkg(name string) (Arch, error) {
+	return parse(name, func(n names) string { return n.dpkg }, nil)
+}
+
+// ParseRPM returns the architecture with the given RPM name.
+func ParseRPM(name string) (Arch, error) {
+	return parse(name, func(n names) string { return n.rpm }, map[string]Arch{
+		"i386":   I386,
+		"i586":   I386,
+		"armhfp": ARMHF,
+	})
+}
+
+// ParseGOARCH returns the architecture with the given Go name.
+func ParseGOARCH(name string) (Arch, error) {
+	return parse(name, func(n names) string { return n.goarch }, nil)
+}
+
+// Parse returns the architecture named in any of the naming schemes
+// understood by this package.
+func Parse(name string) (Arch, error) {
+	for _, p := range []func(string) (Arch, error){
+		parseArch, ParseKernel, ParseDpkg, ParseRPM, ParseGOARCH,
+	} {
+		if a, err := p(name); err == nil {
+			return a, nil
+		}
+	}
+	return "", errors.NotValidf("architecture %q", name)
+}
+
+func parseArch(name string) (Arch, error) {
+	if a := Arch(strings.ToLower(name)); a.IsValid() {
+		return a, nil
+	}
+	return "", errors.NotValidf("architecture %q", name)
+}
+
+func parse(name string, field func(names) string, aliases map[string]Arch) (Arch, error) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for a, n := range archNames {
+		if field(n) == lower {
+			return a, nil
+		}
+	}
+	if a, ok := aliases[lower]; ok {
+		return a, nil
+	}
+	return "", errors.NotValidf("architecture %q", name)
+}
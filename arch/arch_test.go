@@ -0,0 +1,89 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package arch_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/arch"
+)
+
+type archSuite struct {
+}
+
+var _ = gc.Suite(&archSuite{})
+
+var archNamesTests = []struct {
+	arch   arch.Arch
+	kernel string
+	dpkg   string
+	rpm    string
+	goarch string
+	bits   int
+}{
+	{arch: arch.AMD64, kernel: "x86_64", dpkg: "amd64", rpm: "x86_64", goarch: "amd64", bits: 64},
+	{arch: arch.ARM64, kernel: "aarch64", dpkg: "arm64", rpm: "aarch64", goarch: "arm64", bits: 64},
+	{arch: arch.PPC64EL, kernel: "ppc64le", dpkg: "ppc64el", rpm: "ppc64le", goarch: "ppc64le", bits: 64},
+	{arch: arch.S390X, kernel: "s390x", dpkg: "s390x", rpm: "s390x", goarch: "s390x", bits: 64},
+	{arch: arch.RISCV64, kernel: "riscv64", dpkg: "riscv64", rpm: "riscv64", goarch: "riscv64", bits: 64},
+	{arch: arch.I386, kernel: "i686", dpkg: "i386", rpm: "i686", goarch: "386", bits: 32},
+	{arch: arch.ARMHF, kernel: "armv7l", dpkg: "armhf", rpm: "armv7hl", goarch: "arm", bits: 32},
+}
+
+func (s *archSuite) TestNames(c *gc.C) {
+	c.Assert(arch.AllArches(), gc.HasLen, len(archNamesTests))
+	for i, t := range archNamesTests {
+		c.Logf("test %d: %s", i, t.arch)
+		c.Check(t.arch.IsValid(), jc.IsTrue)
+		c.Check(t.arch.Kernel(), gc.Equals, t.kernel)
+		c.Check(t.arch.Dpkg(), gc.Equals, t.dpkg)
+		c.Check(t.arch.RPM(), gc.Equals, t.rpm)
+		c.Check(t.arch.GOARCH(), gc.Equals, t.goarch)
+		c.Check(t.arch.Bits(), gc.Equals, t.bits)
+
+		for _, parse := range []struct {
+			f    func(string) (arch.Arch, error)
+			name string
+		}{
+			{f: arch.ParseKernel, name: t.kernel},
+			{f: arch.ParseDpkg, name: t.dpkg},
+			{f: arch.ParseRPM, name: t.rpm},
+			{f: arch.ParseGOARCH, name: t.goarch},
+			{f: arch.Parse, name: t.arch.String()},
+			{f: arch.Parse, name: t.kernel},
+			{f: arch.Parse, name: t.goarch},
+		} {
+			a, err := parse.f(parse.name)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(a, gc.Equals, t.arch)
+		}
+	}
+}
+
+func (s *archSuite) TestParseKernelAliases(c *gc.C) {
+	for name, expected := range map[string]arch.Arch{
+		"i386":   arch.I386,
+		"i586":   arch.I386,
+		"armv8l": arch.ARMHF,
+		"arm64":  arch.ARM64,
+		"amd64":  arch.AMD64,
+		"X86_64": arch.AMD64,
+	} {
+		a, err := arch.ParseKernel(name)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(a, gc.Equals, expected, gc.Commentf("%s", name))
+	}
+}
+
+func (s *archSuite) TestParseInvalid(c *gc.C) {
+	_, err := arch.ParseKernel("mips")
+	c.Check(err, gc.ErrorMatches, `architecture "mips" not valid`)
+	_, err = arch.ParseDpkg("x86_64")
+	c.Check(err, gc.ErrorMatches, `architecture "x86_64" not valid`)
+	_, err = arch.Parse("sparc64")
+	c.Check(err, gc.ErrorMatches, `architecture "sparc64" not valid`)
+	c.Check(arch.Arch("sparc64").IsValid(), jc.IsFalse)
+	c.Check(arch.Arch("sparc64").Bits(), gc.Equals, 0)
+}
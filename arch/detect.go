@@ -0,0 +1,119 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package arch
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+)
+
+// Host describes the architectures of a machine's kernel and userland.
+type Host struct {
+	// Kernel is the architecture of the running kernel.
+	Kernel Arch
+	// Userland is the architecture of the installed programs. This is
+	// a 32-bit architecture when a 32-bit userland runs on a 64-bit
+	// kernel, as on many Raspberry Pi installations.
+	Userland Arch
+}
+
+// Is32BitUserland reports whether a 32-bit userland runs on a 64-bit
+// kernel.
+func (h Host) Is32BitUserland() bool {
+	return h.Kernel.Bits() == 64 && h.Userland.Bits() == 32
+}
+
+// HostArch returns the architectures of the host. The kernel architecture
+// is reported by uname, and the userland is read relative to os.HostRoot.
+func HostArch() (Host, error) {
+	machine, err := unameMachine()
+	if err != nil {
+		return Host{}, errors.Annotate(err, "cannot determine kernel architecture")
+	}
+	return Detect(jujuos.HostFS(), machine)
+}
+
+// userlandBinaries are the programs whose ELF header identifies the
+// architecture of the userland. The first that can be read is used.
+var userlandBinaries = []string{"/bin/sh", "/usr/bin/env", "/sbin/init"}
+
+// Detect returns the architectures of the root filesystem fsys, running on
+// a kernel that reports the given machine name, as returned by uname -m. If
+// none of the userland programs can be read, the userland architecture is
+// taken to be that of the kernel. Programs that are not ELF executables,
+// or are built for an unknown machine, are passed over.
+func Detect(fsys fs.FS, machine string) (Host, error) {
+	kernel, err := ParseKernel(machine)
+	if err != nil {
+		return Host{}, errors.Trace(err)
+	}
+	host := Host{Kernel: kernel, Userland: kernel}
+	for _, name := range userlandBinaries {
+		// A script, such as a busybox wrapper, or a machine this package
+		// does not know says nothing about the userland, so it is skipped.
+		userland, err := elfArch(fsys, name)
+		if os.IsNotExist(err) || os.IsPermission(err) || errors.IsNotValid(err) || errors.IsNotSupported(err) {
+			continue
+		} else if err != nil {
+			return Host{}, errors.Annotatef(err, "reading %s", name)
+		}
+		host.Userland = userland
+		break
+	}
+	return host, nil
+}
+
+// elfArch returns the architecture of the ELF executable at the absolute
+// path name in fsys.
+func elfArch(fsys fs.FS, name string) (Arch, error) {
+	f, err := fsys.Open(strings.TrimPrefix(path.Clean(name), "/"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// The identification bytes are followed by the 16 bit type and
+	// machine fields.
+	header := make([]byte, elf.EI_NIDENT+4)
+	if _, err := io.ReadFull(f, header); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return "", errors.NotValidf("ELF header")
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if string(header[:4]) != elf.ELFMAG {
+		return "", errors.NotValidf("ELF header")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if elf.Data(header[elf.EI_DATA]) == elf.ELFDATA2MSB {
+		order = binary.BigEndian
+	}
+	class := elf.Class(header[elf.EI_CLASS])
+	machine := elf.Machine(order.Uint16(header[elf.EI_NIDENT+2:]))
+
+	switch {
+	case machine == elf.EM_X86_64 && class == elf.ELFCLASS64:
+		return AMD64, nil
+	case machine == elf.EM_AARCH64:
+		return ARM64, nil
+	case machine == elf.EM_PPC64 && order == binary.LittleEndian:
+		return PPC64EL, nil
+	case machine == elf.EM_S390 && class == elf.ELFCLASS64:
+		return S390X, nil
+	case machine == elf.EM_RISCV && class == elf.ELFCLASS64:
+		return RISCV64, nil
+	case machine == elf.EM_386:
+		return I386, nil
+	case machine == elf.EM_ARM:
+		return ARMHF, nil
+	}
+	return "", errors.NotSupportedf("ELF machine %v (%v)", machine, class)
+}
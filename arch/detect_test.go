@@ -0,0 +1,145 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package arch_test
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"runtime"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/arch"
+)
+
+type detectSuite struct {
+}
+
+var _ = gc.Suite(&detectSuite{})
+
+// elfHeader returns the start of an ELF executable header.
+func elfHeader(class elf.Class, data elf.Data, machine elf.Machine) []byte {
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_CLASS] = byte(class)
+	header[elf.EI_DATA] = byte(data)
+	header[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	var order binary.ByteOrder = binary.LittleEndian
+	if data == elf.ELFDATA2MSB {
+		order = binary.BigEndian
+	}
+	order.PutUint16(header[elf.EI_NIDENT:], uint16(elf.ET_EXEC))
+	order.PutUint16(header[elf.EI_NIDENT+2:], uint16(machine))
+	return header
+}
+
+var detectTests = []struct {
+	message  string
+	machine  string
+	header   []byte
+	kernel   arch.Arch
+	userland arch.Arch
+}{{
+	message:  "amd64",
+	machine:  "x86_64",
+	header:   elfHeader(elf.ELFCLASS64, elf.ELFDATA2LSB, elf.EM_X86_64),
+	kernel:   arch.AMD64,
+	userland: arch.AMD64,
+}, {
+	message:  "i386 userland on amd64",
+	machine:  "x86_64",
+	header:   elfHeader(elf.ELFCLASS32, elf.ELFDATA2LSB, elf.EM_386),
+	kernel:   arch.AMD64,
+	userland: arch.I386,
+}, {
+	message:  "armhf userland on arm64",
+	machine:  "aarch64",
+	header:   elfHeader(elf.ELFCLASS32, elf.ELFDATA2LSB, elf.EM_ARM),
+	kernel:   arch.ARM64,
+	userland: arch.ARMHF,
+}, {
+	message:  "ppc64el",
+	machine:  "ppc64le",
+	header:   elfHeader(elf.ELFCLASS64, elf.ELFDATA2LSB, elf.EM_PPC64),
+	kernel:   arch.PPC64EL,
+	userland: arch.PPC64EL,
+}, {
+	message:  "s390x",
+	machine:  "s390x",
+	header:   elfHeader(elf.ELFCLASS64, elf.ELFDATA2MSB, elf.EM_S390),
+	kernel:   arch.S390X,
+	userland: arch.S390X,
+}, {
+	message:  "riscv64",
+	machine:  "riscv64",
+	header:   elfHeader(elf.ELFCLASS64, elf.ELFDATA2LSB, elf.EM_RISCV),
+	kernel:   arch.RISCV64,
+	userland: arch.RISCV64,
+}, {
+	message:  "no userland",
+	machine:  "aarch64",
+	kernel:   arch.ARM64,
+	userland: arch.ARM64,
+}}
+
+func (s *detectSuite) TestDetect(c *gc.C) {
+	for i, t := range detectTests {
+		c.Logf("test %d: %s", i, t.message)
+		fsys := fstest.MapFS{}
+		if t.header != nil {
+			fsys["bin/sh"] = &fstest.MapFile{Data: t.header}
+		}
+		host, err := arch.Detect(fsys, t.machine)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(host.Kernel, gc.Equals, t.kernel)
+		c.Check(host.Userland, gc.Equals, t.userland)
+		c.Check(host.Is32BitUserland(), gc.Equals, t.kernel.Bits() == 64 && t.userland.Bits() == 32)
+	}
+}
+
+func (s *detectSuite) TestDetectFallsBackToOtherBinaries(c *gc.C) {
+	host, err := arch.Detect(fstest.MapFS{
+		"usr/bin/env": {Data: elfHeader(elf.ELFCLASS32, elf.ELFDATA2LSB, elf.EM_ARM)},
+	}, "aarch64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(host.Userland, gc.Equals, arch.ARMHF)
+}
+
+func (s *detectSuite) TestDetectInvalid(c *gc.C) {
+	_, err := arch.Detect(fstest.MapFS{}, "mips")
+	c.Check(err, gc.ErrorMatches, `architecture "mips" not valid`)
+}
+
+func (s *detectSuite) TestDetectUnknownUserland(c *gc.C) {
+	for i, contents := range [][]byte{
+		[]byte("#!/bin/busybox sh\n#####################"),
+		[]byte("#!/bin/sh\n"),
+		elfHeader(elf.ELFCLASS32, elf.ELFDATA2MSB, elf.EM_MIPS),
+	} {
+		c.Logf("test %d", i)
+		host, err := arch.Detect(fstest.MapFS{
+			"bin/sh": {Data: contents},
+		}, "x86_64")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(host, jc.DeepEquals, arch.Host{Kernel: arch.AMD64, Userland: arch.AMD64})
+	}
+
+	host, err := arch.Detect(fstest.MapFS{
+		"bin/sh":      {Data: []byte("#!/bin/busybox sh\n")},
+		"usr/bin/env": {Data: elfHeader(elf.ELFCLASS32, elf.ELFDATA2LSB, elf.EM_ARM)},
+	}, "aarch64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(host.Userland, gc.Equals, arch.ARMHF)
+}
+
+func (s *detectSuite) TestHostArch(c *gc.C) {
+	host, err := arch.HostArch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(host.Kernel.IsValid(), jc.IsTrue)
+	if runtime.GOOS == "linux" {
+		c.Check(host.Userland.GOARCH(), gc.Equals, runtime.GOARCH)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package arch
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// unameMachine returns the machine name of the architecture the current
+// process was built for, as there is no uname.
+func unameMachine() (string, error) {
+	a, err := ParseGOARCH(runtime.GOARCH)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return a.Kernel(), nil
+}
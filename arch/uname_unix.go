@@ -0,0 +1,20 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package arch
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// unameMachine returns the machine name of the running kernel.
+func unameMachine() (string, error) {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return "", err
+	}
+	return unix.ByteSliceToString(uts.Machine[:]), nil
+}
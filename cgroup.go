@@ -0,0 +1,233 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// CgroupMode identifies the cgroup hierarchies a machine uses.
+type CgroupMode int
+
+const (
+	// UnknownCgroupMode means no cgroup hierarchy was found.
+	UnknownCgroupMode CgroupMode = iota
+	// CgroupV1 is the legacy mode, with a hierarchy per controller.
+	CgroupV1
+	// CgroupV2 is the unified mode, with a single hierarchy.
+	CgroupV2
+	// CgroupHybrid mounts the controllers in v1 hierarchies alongside a
+	// v2 hierarchy, usually at /sys/fs/cgroup/unified, that has none.
+	CgroupHybrid
+)
+
+var cgroupModeNames = map[CgroupMode]string{
+	UnknownCgroupMode: "unknown",
+	CgroupV1:          "v1",
+	CgroupV2:          "v2",
+	CgroupHybrid:      "hybrid",
+}
+
+func (m CgroupMode) String() string {
+	if name, ok := cgroupModeNames[m]; ok {
+		return name
+	}
+	return cgroupModeNames[UnknownCgroupMode]
+}
+
+// Cgroups describes the cgroup hierarchies of a machine.
+type Cgroups struct {
+	// Mode is the mode the hierarchies are mounted in.
+	Mode CgroupMode
+	// Controllers are the controllers available in the mounted
+	// hierarchies, sorted by name. For a v2 hierarchy these are the
+	// controllers delegated to it.
+	Controllers []string
+	// KernelControllers are the controllers enabled in the kernel, as
+	// reported by /proc/cgroups, sorted by name. Controllers that only
+	// exist in v2, such as "io", are not reported there.
+	KernelControllers []string
+	// UnifiedPath is where the v2 hierarchy is mounted, if it is.
+	UnifiedPath string
+}
+
+// HostCgroups returns the cgroup hierarchies of the host, read relative to
+// HostRoot.
+func HostCgroups() (Cgroups, error) {
+	return DetectCgroups(HostFS())
+}
+
+// defaultCgroupRoot is where the cgroup hierarchies are usually mounted.
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// cgroupV1Options are the options of a v1 hierarchy mount that are not
+// controllers.
+var cgroupV1Options = map[string]bool{
+	"rw":             true,
+	"ro":             true,
+	"xattr":          true,
+	"noprefix":       true,
+	"clone_children": true,
+	"cpuset_v2_mode": true,
+	"favordynmods":   true,
+	"none":           true,
+}
+
+// unifiedCgroupRoots are where a v2 hierarchy is mounted in the unified
+// and hybrid modes, preferred first.
+var unifiedCgroupRoots = []string{defaultCgroupRoot, defaultCgroupRoot + "/unified"}
+
+// DetectCgroups returns the cgroup hierarchies described by the root
+// filesystem fsys, which is expected to include /proc and /sys. The
+// hierarchies are found from the mounts of /proc/self/mountinfo, falling
+// back to a v2 hierarchy at /sys/fs/cgroup if it cannot be read. If the
+// v2 hierarchy is mounted more than once, the mount at /sys/fs/cgroup or
+// /sys/fs/cgroup/unified is described.
+func DetectCgroups(fsys fs.FS) (Cgroups, error) {
+	var result Cgroups
+	controllers := make(map[string]bool)
+
+	mountinfo, ok, err := readProbeFile(fsys, "/proc/self/mountinfo")
+	if err != nil {
+		return Cgroups{}, errors.Trace(err)
+	}
+	hasV1 := false
+	if ok {
+		for _, line := range strings.Split(string(mountinfo), "\n") {
+			mountPoint, fsType, options, ok := parseMountInfoLine(line)
+			if !ok {
+				continue
+			}
+			switch fsType {
+			case "cgroup":
+				hasV1 = true
+				for _, option := range strings.Split(options, ",") {
+					if !cgroupV1Options[option] && !strings.Contains(option, "=") {
+						controllers[option] = true
+					}
+				}
+			case "cgroup2":
+				if result.UnifiedPath == "" || unifiedCgroupRootRank(mountPoint) < unifiedCgroupRootRank(result.UnifiedPath) {
+					result.UnifiedPath = mountPoint
+				}
+			}
+		}
+	} else if exists, err := probeExists(fsys, path.Join(defaultCgroupRoot, "cgroup.controllers")); err != nil {
+		return Cgroups{}, errors.Trace(err)
+	} else if exists {
+		result.UnifiedPath = defaultCgroupRoot
+	}
+
+	if result.UnifiedPath != "" {
+		unified, _, err := readProbeFile(fsys, path.Join(result.UnifiedPath, "cgroup.controllers"))
+		if err != nil {
+			return Cgroups{}, errors.Trace(err)
+		}
+		for _, controller := range strings.Fields(string(unified)) {
+			controllers[controller] = true
+		}
+	}
+
+	switch {
+	case hasV1 && result.UnifiedPath != "":
+		result.Mode = CgroupHybrid
+	case hasV1:
+		result.Mode = CgroupV1
+	case result.UnifiedPath != "":
+		result.Mode = CgroupV2
+	}
+	result.Controllers = sortedKeys(controllers)
+
+	// Each line holds the name, hierarchy ID, number of cgroups and
+	// whether the controller is enabled.
+	kernel, _, err := readProbeFile(fsys, "/proc/cgroups")
+	if err != nil {
+		return Cgroups{}, errors.Trace(err)
+	}
+	for _, line := range strings.Split(string(kernel), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[3] == "1" {
+			result.KernelControllers = append(result.KernelControllers, fields[0])
+		}
+	}
+	sort.Strings(result.KernelControllers)
+	return result, nil
+}
+
+// unifiedCgroupRootRank returns the preference for a v2 hierarchy mounted
+// at mountPoint, lower being preferred.
+func unifiedCgroupRootRank(mountPoint string) int {
+	for i, root := range unifiedCgroupRoots {
+		if mountPoint == root {
+			return i
+		}
+	}
+	return len(unifiedCgroupRoots)
+}
+
+// parseMountInfoLine returns the mount point, filesystem type and super
+// block options of a line of /proc/*/mountinfo, such as
+//
+//	30 23 0:26 / /sys/fs/cgroup rw,nosuid shared:9 - cgroup2 cgroup2 rw,nsdelegate
+//
+// The optional fields before the "-" separator vary in number.
+func parseMountInfoLine(line string) (string, string, string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return "", "", "", false
+	}
+	for i := 5; i < len(fields); i++ {
+		if fields[i] != "-" {
+			continue
+		}
+		if i+3 >= len(fields) {
+			return "", "", "", false
+		}
+		return unescapeMountInfo(fields[4]), fields[i+1], fields[i+3], true
+	}
+	return "", "", "", false
+}
+
+// unescapeMountInfo decodes the octal escapes used in mountinfo for
+// characters such as spaces in paths.
+func unescapeMountInfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && isOctal(s[i+1]) && isOctal(s[i+2]) && isOctal(s[i+3]) {
+			b.WriteByte((s[i+1]-'0')<<6 | (s[i+2]-'0')<<3 | (s[i+3] - '0'))
+			i += 3
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
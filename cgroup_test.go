@@ -0,0 +1,210 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type cgroupSuite struct {
+}
+
+var _ = gc.Suite(&cgroupSuite{})
+
+const (
+	// unifiedMountInfo is from Ubuntu 22.04.
+	unifiedMountInfo = `24 30 0:22 / /sys rw,nosuid,nodev,noexec,relatime shared:7 - sysfs sysfs rw
+25 30 0:23 / /proc rw,nosuid,nodev,noexec,relatime shared:13 - proc proc rw
+30 1 252:1 / / rw,relatime shared:1 - ext4 /dev/vda1 rw,discard,errors=remount-ro
+34 24 0:28 / /sys/fs/cgroup rw,nosuid,nodev,noexec,relatime shared:9 - cgroup2 cgroup2 rw,nsdelegate,memory_recursiveprot
+`
+	// hybridMountInfo is from Ubuntu 20.04.
+	hybridMountInfo = `24 30 0:22 / /sys rw,nosuid,nodev,noexec,relatime shared:7 - sysfs sysfs rw
+35 24 0:29 / /sys/fs/cgroup ro,nosuid,nodev,noexec shared:9 - tmpfs tmpfs ro,mode=755
+36 35 0:30 / /sys/fs/cgroup/unified rw,nosuid,nodev,noexec,relatime shared:10 - cgroup2 cgroup2 rw,nsdelegate
+37 35 0:31 / /sys/fs/cgroup/systemd rw,nosuid,nodev,noexec,relatime shared:11 - cgroup cgroup rw,xattr,name=systemd
+40 35 0:34 / /sys/fs/cgroup/cpu,cpuacct rw,nosuid,nodev,noexec,relatime shared:15 - cgroup cgroup rw,cpu,cpuacct
+41 35 0:35 / /sys/fs/cgroup/memory rw,nosuid,nodev,noexec,relatime shared:16 - cgroup cgroup rw,memory
+42 35 0:36 / /sys/fs/cgroup/pids rw,nosuid,nodev,noexec,relatime shared:17 - cgroup cgroup rw,pids
+`
+	// legacyMountInfo is from CentOS 7, which does not mount a v2
+	// hierarchy.
+	legacyMountInfo = `18 61 0:17 / /sys rw,nosuid,nodev,noexec,relatime shared:6 - sysfs sysfs rw
+24 18 0:20 / /sys/fs/cgroup ro,nosuid,nodev,noexec shared:3 - tmpfs tmpfs ro,mode=755
+25 24 0:21 / /sys/fs/cgroup/systemd rw,nosuid,nodev,noexec,relatime shared:4 - cgroup cgroup rw,xattr,release_agent=/usr/lib/systemd/systemd-cgroups-agent,name=systemd
+28 24 0:24 / /sys/fs/cgroup/cpuset rw,nosuid,nodev,noexec,relatime shared:8 - cgroup cgroup rw,cpuset
+29 24 0:25 / /sys/fs/cgroup/memory rw,nosuid,nodev,noexec,relatime shared:9 - cgroup cgroup rw,memory
+`
+	// nestedMountInfo has the v2 hierarchy mounted again in a container
+	// runtime's directory, and a v1 hierarchy mounted with the "none"
+	// option.
+	nestedMountInfo = `24 30 0:22 / /sys rw,nosuid,nodev,noexec,relatime shared:7 - sysfs sysfs rw
+33 30 0:28 / /run/containerd/cgroup rw,nosuid,nodev,noexec,relatime shared:9 - cgroup2 cgroup2 rw,nsdelegate
+34 24 0:28 / /sys/fs/cgroup rw,nosuid,nodev,noexec,relatime shared:9 - cgroup2 cgroup2 rw,nsdelegate
+36 30 0:40 / /run/cgmanager/fs/none rw,relatime - cgroup none rw,none,name=elogind
+`
+	procCgroups = `#subsys_name	hierarchy	num_cgroups	enabled
+cpuset	0	102	1
+cpu	0	102	1
+cpuacct	0	102	1
+memory	0	102	1
+pids	0	102	1
+rdma	0	102	0
+`
+)
+
+var detectCgroupsTests = []struct {
+	message  string
+	files    fstest.MapFS
+	expected Cgroups
+}{{
+	message:  "nothing mounted",
+	files:    fstest.MapFS{},
+	expected: Cgroups{},
+}, {
+	message: "unified",
+	files: fstest.MapFS{
+		"proc/self/mountinfo":              {Data: []byte(unifiedMountInfo)},
+		"proc/cgroups":                     {Data: []byte(procCgroups)},
+		"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpuset cpu io memory hugetlb pids rdma misc\n")},
+	},
+	expected: Cgroups{
+		Mode:              CgroupV2,
+		Controllers:       []string{"cpu", "cpuset", "hugetlb", "io", "memory", "misc", "pids", "rdma"},
+		KernelControllers: []string{"cpu", "cpuacct", "cpuset", "memory", "pids"},
+		UnifiedPath:       "/sys/fs/cgroup",
+	},
+}, {
+	message: "unified with a subset delegated",
+	files: fstest.MapFS{
+		"proc/self/mountinfo":              {Data: []byte(unifiedMountInfo)},
+		"sys/fs/cgroup/cgroup.controllers": {Data: []byte("memory pids\n")},
+	},
+	expected: Cgroups{
+		Mode:        CgroupV2,
+		Controllers: []string{"memory", "pids"},
+		UnifiedPath: "/sys/fs/cgroup",
+	},
+}, {
+	message: "hybrid",
+	files: fstest.MapFS{
+		"proc/self/mountinfo": {Data: []byte(hybridMountInfo)},
+		"proc/cgroups":        {Data: []byte(procCgroups)},
+		"sys/fs/cgroup/unified/cgroup.controllers": {Data: []byte("\n")},
+	},
+	expected: Cgroups{
+		Mode:              CgroupHybrid,
+		Controllers:       []string{"cpu", "cpuacct", "memory", "pids"},
+		KernelControllers: []string{"cpu", "cpuacct", "cpuset", "memory", "pids"},
+		UnifiedPath:       "/sys/fs/cgroup/unified",
+	},
+}, {
+	message: "legacy",
+	files: fstest.MapFS{
+		"proc/self/mountinfo": {Data: []byte(legacyMountInfo)},
+		"proc/cgroups":        {Data: []byte(procCgroups)},
+	},
+	expected: Cgroups{
+		Mode:              CgroupV1,
+		Controllers:       []string{"cpuset", "memory"},
+		KernelControllers: []string{"cpu", "cpuacct", "cpuset", "memory", "pids"},
+	},
+}, {
+	message: "several v2 mounts",
+	files: fstest.MapFS{
+		"proc/self/mountinfo":              {Data: []byte(nestedMountInfo)},
+		"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+	},
+	expected: Cgroups{
+		Mode:        CgroupHybrid,
+		Controllers: []string{"cpu", "memory"},
+		UnifiedPath: "/sys/fs/cgroup",
+	},
+}, {
+	message: "no mountinfo",
+	files: fstest.MapFS{
+		"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+	},
+	expected: Cgroups{
+		Mode:        CgroupV2,
+		Controllers: []string{"cpu", "memory"},
+		UnifiedPath: "/sys/fs/cgroup",
+	},
+}}
+
+func (s *cgroupSuite) TestDetectCgroups(c *gc.C) {
+	for i, test := range detectCgroupsTests {
+		c.Logf("test %d: %s", i, test.message)
+		cgroups, err := DetectCgroups(test.files)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cgroups, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *cgroupSuite) TestHostCgroupsFollowsHostRoot(c *gc.C) {
+	root := c.MkDir()
+	hosttest.WriteFile(c, root, "proc/self/mountinfo", legacyMountInfo)
+	SetHostRoot(root)
+	defer SetHostRoot("")
+
+	cgroups, err := HostCgroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cgroups, jc.DeepEquals, Cgroups{
+		Mode:        CgroupV1,
+		Controllers: []string{"cpuset", "memory"},
+	})
+}
+
+func (s *cgroupSuite) TestParseMountInfoLine(c *gc.C) {
+	for i, test := range []struct {
+		line       string
+		mountPoint string
+		fsType     string
+		options    string
+		ok         bool
+	}{{
+		line:       "34 24 0:28 / /sys/fs/cgroup rw,nosuid shared:9 - cgroup2 cgroup2 rw,nsdelegate",
+		mountPoint: "/sys/fs/cgroup",
+		fsType:     "cgroup2",
+		options:    "rw,nsdelegate",
+		ok:         true,
+	}, {
+		line:       "40 35 0:34 / /mnt/with\\040space rw - cgroup cgroup rw,cpu",
+		mountPoint: "/mnt/with space",
+		fsType:     "cgroup",
+		options:    "rw,cpu",
+		ok:         true,
+	}, {
+		line:       "30 1 252:1 / / rw,relatime - ext4 /dev/vda1 rw",
+		mountPoint: "/",
+		fsType:     "ext4",
+		options:    "rw",
+		ok:         true,
+	}, {
+		line: "30 1 252:1 / / rw,relatime shared:1",
+	}, {
+		line: "30 1 252:1 / / rw - ext4",
+	}, {
+		line: "",
+	}} {
+		c.Logf("test %d: %q", i, test.line)
+		mountPoint, fsType, options, ok := parseMountInfoLine(test.line)
+		c.Check(ok, gc.Equals, test.ok)
+		c.Check(mountPoint, gc.Equals, test.mountPoint)
+		c.Check(fsType, gc.Equals, test.fsType)
+		c.Check(options, gc.Equals, test.options)
+	}
+}
+
+func (s *cgroupSuite) TestCgroupModeString(c *gc.C) {
+	c.Check(CgroupV1.String(), gc.Equals, "v1")
+	c.Check(CgroupV2.String(), gc.Equals, "v2")
+	c.Check(CgroupHybrid.String(), gc.Equals, "hybrid")
+	c.Check(CgroupMode(99).String(), gc.Equals, "unknown")
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"encoding/json"
+	"io/fs"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Cloud identifies the cloud a machine runs in.
+type Cloud int
+
+const (
+	// UnknownCloud means no cloud was identified.
+	UnknownCloud Cloud = iota
+	AWSCloud
+	GCECloud
+	AzureCloud
+	OpenStackCloud
+	OracleCloud
+	DigitalOceanCloud
+	EquinixCloud
+	// LXDCloud is an LXD virtual machine.
+	LXDCloud
+)
+
+var cloudNames = map[Cloud]string{
+	UnknownCloud:      "unknown",
+	AWSCloud:          "aws",
+	GCECloud:          "gce",
+	AzureCloud:        "azure",
+	OpenStackCloud:    "openstack",
+	OracleCloud:       "oracle",
+	DigitalOceanCloud: "digitalocean",
+	EquinixCloud:      "equinix",
+	LXDCloud:          "lxd",
+}
+
+func (c Cloud) String() string {
+	if name, ok := cloudNames[c]; ok {
+		return name
+	}
+	return cloudNames[UnknownCloud]
+}
+
+// CloudInfo describes the cloud a machine runs in.
+type CloudInfo struct {
+	// Cloud is the identified cloud, or UnknownCloud.
+	Cloud Cloud
+	// Evidence holds every observation that identifies a cloud, in the
+	// order they were made.
+	Evidence []Evidence
+}
+
+// CloudProvider identifies the cloud the host runs in, read relative to
+// HostRoot. No network requests are made.
+func CloudProvider() (CloudInfo, error) {
+	return DetectCloudProvider(HostFS())
+}
+
+// cloudDMIRules match the DMI strings set by each cloud.
+var cloudDMIRules = []dmiRule{
+	{match: map[string]string{"sys_vendor": "Amazon EC2"}, indicates: AWSCloud},
+	{match: map[string]string{"bios_version": "amazon"}, indicates: AWSCloud},
+	{match: map[string]string{"product_name": "Google Compute Engine"}, indicates: GCECloud},
+	{match: map[string]string{"chassis_asset_tag": "7783-7084-3265-9085-8269-3286-77"}, indicates: AzureCloud},
+	{match: map[string]string{"product_name": "OpenStack"}, indicates: OpenStackCloud},
+	{match: map[string]string{"sys_vendor": "OpenStack"}, indicates: OpenStackCloud},
+	{match: map[string]string{"chassis_asset_tag": "OpenTelekomCloud"}, indicates: OpenStackCloud},
+	{match: map[string]string{"chassis_asset_tag": "OracleCloud.com"}, indicates: OracleCloud},
+	{match: map[string]string{"sys_vendor": "DigitalOcean"}, indicates: DigitalOceanCloud},
+	{match: map[string]string{"chassis_asset_tag": "Equinix"}, indicates: EquinixCloud},
+	{match: map[string]string{"board_vendor": "Equinix"}, indicates: EquinixCloud},
+	{match: map[string]string{"board_name": "LXD"}, indicates: LXDCloud},
+}
+
+// cloudInitInstanceData is the instance data written by cloud-init. It is
+// readable by unprivileged users.
+const cloudInitInstanceData = "/run/cloud-init/instance-data.json"
+
+// cloudInitCloudNames maps the cloud names used by cloud-init onto a cloud.
+var cloudInitCloudNames = map[string]Cloud{
+	"aws":          AWSCloud,
+	"ec2":          AWSCloud,
+	"gce":          GCECloud,
+	"azure":        AzureCloud,
+	"openstack":    OpenStackCloud,
+	"oracle":       OracleCloud,
+	"digitalocean": DigitalOceanCloud,
+	"equinix":      EquinixCloud,
+	"packet":       EquinixCloud,
+	"lxd":          LXDCloud,
+}
+
+// DetectCloudProvider identifies the cloud described by the root
+// filesystem fsys from its DMI strings and cloud-init instance data. The
+// cloud is that of the first evidence found, with DMI consulted first.
+// Instance data that cannot be parsed is ignored.
+func DetectCloudProvider(fsys fs.FS) (CloudInfo, error) {
+	dmi, err := readDMI(fsys)
+	if err != nil {
+		return CloudInfo{}, errors.Trace(err)
+	}
+	result := CloudInfo{Evidence: matchDMI(dmi, cloudDMIRules)}
+
+	contents, ok, err := readProbeFile(fsys, cloudInitInstanceData)
+	if err != nil {
+		return CloudInfo{}, errors.Trace(err)
+	} else if ok {
+		var data struct {
+			V1 struct {
+				CloudName string `json:"cloud_name"`
+				Platform  string `json:"platform"`
+			} `json:"v1"`
+		}
+		// cloud-init may be rewriting the file, so one that cannot be
+		// parsed is treated as absent rather than discarding the DMI
+		// evidence.
+		if err := json.Unmarshal(contents, &data); err == nil {
+			for _, name := range []string{data.V1.CloudName, data.V1.Platform} {
+				if cloud, ok := cloudInitCloudNames[strings.ToLower(name)]; ok {
+					result.Evidence = append(result.Evidence, Evidence{
+						Indicates: cloud,
+						Path:      cloudInitInstanceData,
+						Detail:    name,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	for _, e := range result.Evidence {
+		if cloud, ok := e.Indicates.(Cloud); ok {
+			result.Cloud = cloud
+			break
+		}
+	}
+	return result, nil
+}
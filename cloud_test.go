@@ -0,0 +1,160 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type cloudSuite struct {
+}
+
+var _ = gc.Suite(&cloudSuite{})
+
+var detectCloudProviderTests = []struct {
+	message string
+	files   fstest.MapFS
+	cloud   Cloud
+}{{
+	message: "bare metal",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Dell Inc.\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("PowerEdge R640\n")},
+	},
+	cloud: UnknownCloud,
+}, {
+	message: "aws nitro",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Amazon EC2\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("m5.large\n")},
+	},
+	cloud: AWSCloud,
+}, {
+	message: "aws xen",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Xen\n")},
+		"sys/class/dmi/id/bios_version": {Data: []byte("4.11.amazon\n")},
+	},
+	cloud: AWSCloud,
+}, {
+	message: "gce",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Google\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Google Compute Engine\n")},
+	},
+	cloud: GCECloud,
+}, {
+	message: "azure",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":        {Data: []byte("Microsoft Corporation\n")},
+		"sys/class/dmi/id/product_name":      {Data: []byte("Virtual Machine\n")},
+		"sys/class/dmi/id/chassis_asset_tag": {Data: []byte("7783-7084-3265-9085-8269-3286-77\n")},
+	},
+	cloud: AzureCloud,
+}, {
+	message: "openstack",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("OpenStack Foundation\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("OpenStack Nova\n")},
+	},
+	cloud: OpenStackCloud,
+}, {
+	message: "oracle",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/chassis_asset_tag": {Data: []byte("OracleCloud.com\n")},
+	},
+	cloud: OracleCloud,
+}, {
+	message: "digitalocean",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("DigitalOcean\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Droplet\n")},
+	},
+	cloud: DigitalOceanCloud,
+}, {
+	message: "equinix",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/chassis_asset_tag": {Data: []byte("Equinix Metal\n")},
+	},
+	cloud: EquinixCloud,
+}, {
+	message: "lxd vm",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor": {Data: []byte("QEMU\n")},
+		"sys/class/dmi/id/board_name": {Data: []byte("LXD\n")},
+	},
+	cloud: LXDCloud,
+}, {
+	message: "cloud-init instance data",
+	files: fstest.MapFS{
+		"run/cloud-init/instance-data.json": {Data: []byte(`{"v1": {"cloud_name": "digitalocean", "platform": "digitalocean"}}`)},
+	},
+	cloud: DigitalOceanCloud,
+}, {
+	message: "cloud-init unknown cloud",
+	files: fstest.MapFS{
+		"run/cloud-init/instance-data.json": {Data: []byte(`{"v1": {"cloud_name": "unknown", "platform": "nocloud"}}`)},
+	},
+	cloud: UnknownCloud,
+}}
+
+func (s *cloudSuite) TestDetectCloudProvider(c *gc.C) {
+	for i, t := range detectCloudProviderTests {
+		c.Logf("test %d: %s", i, t.message)
+		result, err := DetectCloudProvider(t.files)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Cloud, gc.Equals, t.cloud)
+	}
+}
+
+func (s *cloudSuite) TestDetectCloudProviderEvidence(c *gc.C) {
+	result, err := DetectCloudProvider(fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":       {Data: []byte("Amazon EC2\n")},
+		"run/cloud-init/instance-data.json": {Data: []byte(`{"v1": {"cloud_name": "aws", "platform": "ec2"}}`)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Cloud, gc.Equals, AWSCloud)
+	c.Check(result.Evidence, jc.DeepEquals, []Evidence{{
+		Indicates: AWSCloud,
+		Path:      "/sys/class/dmi/id/sys_vendor",
+		Detail:    "Amazon EC2",
+	}, {
+		Indicates: AWSCloud,
+		Path:      "/run/cloud-init/instance-data.json",
+		Detail:    "aws",
+	}})
+}
+
+func (s *cloudSuite) TestDetectCloudProviderBadInstanceData(c *gc.C) {
+	result, err := DetectCloudProvider(fstest.MapFS{
+		"run/cloud-init/instance-data.json": {Data: []byte(`{"v1":`)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, CloudInfo{})
+}
+
+func (s *cloudSuite) TestDetectCloudProviderBadInstanceDataKeepsDMI(c *gc.C) {
+	result, err := DetectCloudProvider(fstest.MapFS{
+		"sys/class/dmi/id/product_name":     {Data: []byte("Google Compute Engine\n")},
+		"run/cloud-init/instance-data.json": {Data: []byte(`{"v1": {"cloud_name": `)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, CloudInfo{
+		Cloud: GCECloud,
+		Evidence: []Evidence{{
+			Indicates: GCECloud,
+			Path:      "/sys/class/dmi/id/product_name",
+			Detail:    "Google Compute Engine",
+		}},
+	})
+}
+
+func (s *cloudSuite) TestCloudString(c *gc.C) {
+	c.Check(UnknownCloud.String(), gc.Equals, "unknown")
+	c.Check(DigitalOceanCloud.String(), gc.Equals, "digitalocean")
+	c.Check(Cloud(99).String(), gc.Equals, "unknown")
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Command hostos prints what is concluded about the OS of a host: its OS
+// type, series, version and support status, and the files they were read
+// from. The paths of the files are relative to the root filesystem.
+//
+//	hostos [--format=json|yaml|tabular] [--root=DIR]
+//
+// The --root option inspects the root filesystem of a mounted image or
+// chroot rather than the running host.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	goos "os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+// hostInfo is what is concluded about a host.
+type hostInfo struct {
+	OSType       string            `json:"os-type" yaml:"os-type"`
+	Series       string            `json:"series,omitempty" yaml:"series,omitempty"`
+	Version      string            `json:"version,omitempty" yaml:"version,omitempty"`
+	LTS          bool              `json:"lts" yaml:"lts"`
+	Supported    bool              `json:"supported" yaml:"supported"`
+	ESMSupported bool              `json:"esm-supported" yaml:"esm-supported"`
+	Sources      []string          `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Errors       map[string]string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// formats are the output formats understood by write.
+var formats = map[string]bool{
+	"json":    true,
+	"yaml":    true,
+	"tabular": true,
+}
+
+func main() {
+	goos.Exit(run(goos.Args[1:], goos.Stdout, goos.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("hostos", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "tabular", "output format: json, yaml or tabular")
+	root := flags.String("root", "", "directory the root filesystem to inspect is mounted at")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if !formats[*format] {
+		fmt.Fprintf(stderr, "format %q not valid\n", *format)
+		flags.Usage()
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		return 2
+	}
+	if *root != "" {
+		if err := checkRoot(*root); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 2
+		}
+		os.SetHostRoot(*root)
+	}
+
+	if err := write(stdout, *format, gather()); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// checkRoot returns an error if root is not a directory.
+func checkRoot(root string) error {
+	info, err := goos.Stat(root)
+	if goos.IsNotExist(err) {
+		return errors.Errorf("root %q does not exist", root)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("root %q is not a directory", root)
+	}
+	return nil
+}
+
+// gather returns what is concluded about the host, recording the errors
+// of any conclusion that cannot be reached.
+func gather() hostInfo {
+	var info hostInfo
+	recordErr := func(field string, err error) {
+		if err == nil {
+			return
+		}
+		if info.Errors == nil {
+			info.Errors = make(map[string]string)
+		}
+		info.Errors[field] = err.Error()
+	}
+
+	// DetectHostOS is used rather than HostOS, which panics if there is
+	// no release file and does not report why a distribution is not
+	// recognised.
+	osType, err := os.DetectHostOS()
+	info.OSType = osType.String()
+	recordErr("os-type", err)
+	if osType.IsLinux() {
+		if release, err := os.DefaultDetector().Release(); err == nil {
+			info.Sources = append(info.Sources, release.Source)
+		}
+	}
+
+	info.Series, err = series.HostSeries()
+	recordErr("series", err)
+	info.Version = series.ReleaseVersion()
+
+	versionOS, versions, err := series.LocalSeriesVersionInfo()
+	if err != nil {
+		recordErr("distro-info", err)
+	} else if _, err := goos.Stat(os.HostPath(series.UbuntuDistroInfo)); versionOS == os.Ubuntu && err == nil {
+		info.Sources = append(info.Sources, series.UbuntuDistroInfo)
+	}
+	if v, ok := versions[info.Series]; ok {
+		info.LTS = v.LTS
+	}
+	info.Supported = contains(series.SupportedJujuSeries(), info.Series)
+	info.ESMSupported = contains(series.ESMSupportedJujuSeries(), info.Series)
+	return info
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// write writes info to w in the given format.
+func write(w io.Writer, format string, info hostInfo) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return errors.Trace(enc.Encode(info))
+	case "yaml":
+		data, err := yaml.Marshal(info)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = w.Write(data)
+		return errors.Trace(err)
+	case "tabular":
+		return errors.Trace(writeTabular(w, info))
+	}
+	return errors.NotValidf("format %q", format)
+}
+
+func writeTabular(w io.Writer, info hostInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "OS type\t%s\n", info.OSType)
+	fmt.Fprintf(tw, "Series\t%s\n", info.Series)
+	fmt.Fprintf(tw, "Version\t%s\n", info.Version)
+	fmt.Fprintf(tw, "LTS\t%t\n", info.LTS)
+	fmt.Fprintf(tw, "Supported\t%t\n", info.Supported)
+	fmt.Fprintf(tw, "ESM supported\t%t\n", info.ESMSupported)
+	fmt.Fprintf(tw, "Sources\t%s\n", strings.Join(info.Sources, ", "))
+	fields := make([]string, 0, len(info.Errors))
+	for field := range info.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "Error\t%s: %s\n", field, info.Errors[field])
+	}
+	return tw.Flush()
+}
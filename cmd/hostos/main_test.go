@@ -0,0 +1,134 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type mainSuite struct {
+	testing.IsolationSuite
+	root string
+}
+
+var _ = gc.Suite(&mainSuite{})
+
+const jammyOSRelease = `NAME="Ubuntu"
+ID=ubuntu
+ID_LIKE=debian
+VERSION_ID="22.04"
+VERSION_CODENAME=jammy
+UBUNTU_CODENAME=jammy
+`
+
+const distroInfo = `version,codename,series,created,release,eol,eol-server
+22.04 LTS,Jammy Jellyfish,jammy,2021-10-14,2022-04-21,2027-04-21,2027-04-21
+`
+
+func (s *mainSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS != "linux" {
+		c.Skip("the host OS is read from the filesystem on Linux")
+	}
+	s.root = c.MkDir()
+	hosttest.WriteFile(c, s.root, "etc/os-release", jammyOSRelease)
+	hosttest.WriteFile(c, s.root, "usr/share/distro-info/ubuntu.csv", distroInfo)
+	s.AddCleanup(func(*gc.C) { os.SetHostRoot("") })
+}
+
+func (s *mainSuite) runHostOS(c *gc.C, args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func (s *mainSuite) TestJSON(c *gc.C) {
+	stdout, stderr, code := s.runHostOS(c, "--format=json", "--root="+s.root)
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+
+	var info hostInfo
+	err := json.Unmarshal([]byte(stdout), &info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.OSType, gc.Equals, "Ubuntu")
+	c.Check(info.Series, gc.Equals, "jammy")
+	c.Check(info.Version, gc.Equals, "22.04")
+	c.Check(info.LTS, jc.IsTrue)
+	c.Check(info.Sources, jc.DeepEquals, []string{
+		"/etc/os-release",
+		"/usr/share/distro-info/ubuntu.csv",
+	})
+	c.Check(info.Errors, gc.HasLen, 0)
+}
+
+func (s *mainSuite) TestYAML(c *gc.C) {
+	stdout, _, code := s.runHostOS(c, "--format", "yaml", "--root", s.root)
+	c.Assert(code, gc.Equals, 0)
+
+	var info hostInfo
+	err := yaml.Unmarshal([]byte(stdout), &info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.OSType, gc.Equals, "Ubuntu")
+	c.Check(info.Series, gc.Equals, "jammy")
+}
+
+func (s *mainSuite) TestTabular(c *gc.C) {
+	stdout, _, code := s.runHostOS(c, "--root", s.root)
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Matches, `(?s)OS type +Ubuntu
+Series +jammy
+Version +22\.04
+LTS +true
+Supported +(true|false)
+ESM supported +(true|false)
+Sources +/etc/os-release, /usr/share/distro-info/ubuntu\.csv
+`)
+}
+
+func (s *mainSuite) TestErrors(c *gc.C) {
+	stdout, _, code := s.runHostOS(c, "--root", c.MkDir())
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Matches, `(?s)OS type +Unknown
+.*Error +os-type: release file not found .*
+Error +series: cannot determine host series: .*`)
+}
+
+func (s *mainSuite) TestBadFormat(c *gc.C) {
+	stdout, stderr, code := s.runHostOS(c, "--format=xml", "--root", s.root)
+	c.Check(code, gc.Equals, 2)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Matches, "format \"xml\" not valid\nUsage of hostos:\n(.|\n)*-format string(.|\n)*")
+}
+
+func (s *mainSuite) TestBadRoot(c *gc.C) {
+	missing := filepath.Join(s.root, "missing")
+	stdout, stderr, code := s.runHostOS(c, "--root", missing)
+	c.Check(code, gc.Equals, 2)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, fmt.Sprintf("error: root %q does not exist\n", missing))
+
+	file := filepath.Join(s.root, "etc", "os-release")
+	stdout, stderr, code = s.runHostOS(c, "--root", file)
+	c.Check(code, gc.Equals, 2)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, fmt.Sprintf("error: root %q is not a directory\n", file))
+}
+
+func (s *mainSuite) TestUnexpectedArguments(c *gc.C) {
+	_, stderr, code := s.runHostOS(c, "extra")
+	c.Check(code, gc.Equals, 2)
+	c.Check(stderr, gc.Equals, "unexpected arguments: extra\n")
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Command series queries the catalogue of series known to Juju.
+//
+//	series [--distro-info=PATH] list [--supported] [--controller] [--lts] [--esm]
+//	series [--distro-info=PATH] version <series>
+//	series [--distro-info=PATH] series <version>
+//	series [--distro-info=PATH] os <series>
+//	series [--distro-info=PATH] latest-lts
+//
+// The Ubuntu series are read from the distro-info file, which defaults to
+// /usr/share/distro-info/ubuntu.csv.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	goos "os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/os/v2/series"
+)
+
+const usage = `usage: series [--distro-info=PATH] <command> [arguments]
+
+commands:
+  list [--supported] [--controller] [--lts] [--esm]
+                      list the known series, filtered by the given flags
+  version <series>    print the version of a series
+  series <version>    print the series of a version
+  os <series>         print the OS type of a series
+  latest-lts          print the latest supported LTS series
+`
+
+// usageError is returned when the command line is not valid.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func main() {
+	goos.Exit(run(goos.Args[1:], goos.Stdout, goos.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("series", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	distroInfo := flags.String("distro-info", "", "path of the Ubuntu distro-info file")
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+
+	err := setDistroInfo(*distroInfo)
+	if err == nil {
+		err = runCommand(flags.Args(), stdout, stderr)
+	}
+	if _, ok := errors.Cause(err).(usageError); ok {
+		fmt.Fprintf(stderr, "%v\n%s", err, usage)
+		return 2
+	} else if errors.Cause(err) == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// setDistroInfo reads the Ubuntu series from the distro-info file at path,
+// if one is given.
+func setDistroInfo(path string) error {
+	if path == "" {
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := goos.Stat(path); err != nil {
+		return errors.Annotate(err, "reading distro-info")
+	}
+	series.UbuntuDistroInfo = path
+	return errors.Annotate(series.UpdateSeriesVersions(), "reading distro-info")
+}
+
+func runCommand(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return usageError("no command given")
+	}
+	command, args := args[0], args[1:]
+	switch command {
+	case "list":
+		return list(args, stdout, stderr)
+	case "version":
+		return lookup(args, "series", stdout, series.SeriesVersion)
+	case "series":
+		return lookup(args, "version", stdout, series.VersionSeries)
+	case "os":
+		return lookup(args, "series", stdout, func(s string) (string, error) {
+			osType, err := series.GetOSFromSeries(s)
+			return osType.String(), err
+		})
+	case "latest-lts":
+		if len(args) != 0 {
+			return usageError("latest-lts takes no arguments")
+		}
+		latest := series.LatestLts()
+		if latest == "" {
+			return errors.NotFoundf("supported LTS series")
+		}
+		fmt.Fprintln(stdout, latest)
+		return nil
+	}
+	return usageError(fmt.Sprintf("unknown command %q", command))
+}
+
+// lookup prints the result of calling f with the single argument, which
+// is described by name.
+func lookup(args []string, name string, stdout io.Writer, f func(string) (string, error)) error {
+	if len(args) != 1 {
+		return usageError(fmt.Sprintf("expected a single %s", name))
+	}
+	result, err := f(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintln(stdout, result)
+	return nil
+}
+
+// list prints the known series that match every given filter. Series that
+// are supported by Juju are listed in the order of SupportedJujuSeries,
+// otherwise series are listed by name.
+func list(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	supported := flags.Bool("supported", false, "only list series Juju supports for workloads")
+	controller := flags.Bool("controller", false, "only list series Juju supports for controllers")
+	lts := flags.Bool("lts", false, "only list Ubuntu LTS series")
+	esm := flags.Bool("esm", false, "only list series with extended security maintenance")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return usageError("list takes no arguments")
+	}
+
+	all := series.SupportedSeries()
+	sort.Strings(all)
+	candidates := append(series.SupportedJujuWorkloadSeries(), all...)
+
+	var filters []func(string) bool
+	if *supported {
+		filters = append(filters, in(series.SupportedJujuWorkloadSeries()))
+	}
+	if *controller {
+		filters = append(filters, in(series.SupportedJujuControllerSeries()))
+	}
+	if *esm {
+		filters = append(filters, in(series.ESMSupportedJujuSeries()))
+	}
+	if *lts {
+		_, versions, err := series.LocalSeriesVersionInfo()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filters = append(filters, func(s string) bool {
+			return versions[s].LTS
+		})
+	}
+
+	listed := make(map[string]bool)
+next:
+	for _, s := range candidates {
+		if listed[s] {
+			continue
+		}
+		for _, filter := range filters {
+			if !filter(s) {
+				continue next
+			}
+		}
+		listed[s] = true
+		fmt.Fprintln(stdout, s)
+	}
+	return nil
+}
+
+// in returns a filter that matches the given series.
+func in(values []string) func(string) bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return func(s string) bool {
+		return set[s]
+	}
+}
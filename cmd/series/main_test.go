@@ -0,0 +1,156 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type mainSuite struct {
+	testing.IsolationSuite
+	distroInfo string
+}
+
+var _ = gc.Suite(&mainSuite{})
+
+const distroInfo = `version,codename,series,created,release,eol,eol-server
+12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26
+22.04 LTS,Jammy Jellyfish,jammy,2021-10-14,2022-04-21,2027-04-21,2027-04-21
+98.10,Star Date,stardate,2363-10-13,2364-04-25,2364-07-17
+99.04 LTS,Star Trek,spock,2364-04-25,2364-10-17,2369-07-17,2369-07-17
+`
+
+func (s *mainSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.distroInfo = filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(s.distroInfo, []byte(distroInfo), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&series.UbuntuDistroInfo, series.UbuntuDistroInfo)
+}
+
+func (s *mainSuite) runSeries(c *gc.C, args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"--distro-info", s.distroInfo}, args...), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func (s *mainSuite) TestVersion(c *gc.C) {
+	stdout, stderr, code := s.runSeries(c, "version", "jammy")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+	c.Check(stdout, gc.Equals, "22.04\n")
+}
+
+func (s *mainSuite) TestVersionUnknown(c *gc.C) {
+	_, stderr, code := s.runSeries(c, "version", "bogus")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr, gc.Matches, `error: .*"bogus".*\n`)
+}
+
+func (s *mainSuite) TestSeries(c *gc.C) {
+	stdout, _, code := s.runSeries(c, "series", "22.04")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "jammy\n")
+}
+
+func (s *mainSuite) TestOS(c *gc.C) {
+	stdout, _, code := s.runSeries(c, "os", "centos7")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "CentOS\n")
+}
+
+func (s *mainSuite) TestDistroInfo(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("distro-info is only read on Linux")
+	}
+	stdout, _, code := s.runSeries(c, "version", "spock")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "99.04\n")
+
+	stdout, _, code = s.runSeries(c, "os", "spock")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "Ubuntu\n")
+}
+
+func (s *mainSuite) TestDistroInfoWithHostRoot(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("distro-info is only read on Linux")
+	}
+	// The given distro-info path is not relative to the host root.
+	s.PatchEnvironment(os.HostRootEnvVar, c.MkDir())
+	stdout, stderr, code := s.runSeries(c, "version", "spock")
+	c.Check(stderr, gc.Equals, "")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "99.04\n")
+}
+
+func (s *mainSuite) TestDistroInfoMissing(c *gc.C) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--distro-info", filepath.Join(c.MkDir(), "missing.csv"), "latest-lts"}, &stdout, &stderr)
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr.String(), gc.Matches, "error: reading distro-info: .*no such file or directory\n")
+}
+
+func (s *mainSuite) TestList(c *gc.C) {
+	stdout, _, code := s.runSeries(c, "list")
+	c.Check(code, gc.Equals, 0)
+	listed := make(map[string]bool)
+	for _, s := range strings.Fields(stdout) {
+		listed[s] = true
+	}
+	for _, s := range append(series.SupportedSeries(), series.SupportedJujuWorkloadSeries()...) {
+		c.Check(listed[s], jc.IsTrue, gc.Commentf("series %q", s))
+	}
+
+	stdout, _, code = s.runSeries(c, "list", "--supported")
+	c.Check(code, gc.Equals, 0)
+	c.Check(strings.Fields(stdout), jc.DeepEquals, series.SupportedJujuWorkloadSeries())
+
+	stdout, _, code = s.runSeries(c, "list", "--esm")
+	c.Check(code, gc.Equals, 0)
+	c.Check(strings.Fields(stdout), jc.SameContents, series.ESMSupportedJujuSeries())
+}
+
+func (s *mainSuite) TestListLTS(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("distro-info is only read on Linux")
+	}
+	stdout, _, code := s.runSeries(c, "list", "--lts")
+	c.Check(code, gc.Equals, 0)
+	listed := make(map[string]bool)
+	for _, s := range strings.Fields(stdout) {
+		listed[s] = true
+	}
+	c.Check(listed["jammy"], jc.IsTrue)
+	c.Check(listed["spock"], jc.IsTrue)
+	c.Check(listed["stardate"], jc.IsFalse)
+	c.Check(listed["centos7"], jc.IsFalse)
+}
+
+func (s *mainSuite) TestUsage(c *gc.C) {
+	for _, args := range [][]string{
+		{},
+		{"bogus"},
+		{"version"},
+		{"series", "1", "2"},
+		{"list", "extra"},
+		{"latest-lts", "extra"},
+	} {
+		c.Logf("args: %v", args)
+		_, stderr, code := s.runSeries(c, args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(stderr, gc.Matches, "(?s).*usage: series .*")
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	goos "os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ContainerRuntime identifies the container technology a process runs in.
+type ContainerRuntime int
+
+const (
+	// NoContainer means no container was detected.
+	NoContainer ContainerRuntime = iota
+	// UnknownContainer is a container whose runtime is not recognised.
+	UnknownContainer
+	LXDContainer
+	LXCContainer
+	DockerContainer
+	PodmanContainer
+	SystemdNspawnContainer
+	// WSLContainer is the Windows Subsystem for Linux, which is treated as
+	// a container as the kernel is not under the control of the system.
+	WSLContainer
+	// KubernetesContainer is a container in a Kubernetes pod, whatever
+	// runtime runs the pod.
+	KubernetesContainer
+)
+
+var containerRuntimeNames = map[ContainerRuntime]string{
+	NoContainer:            "none",
+	UnknownContainer:       "unknown",
+	LXDContainer:           "lxd",
+	LXCContainer:           "lxc",
+	DockerContainer:        "docker",
+	PodmanContainer:        "podman",
+	SystemdNspawnContainer: "systemd-nspawn",
+	WSLContainer:           "wsl",
+	KubernetesContainer:    "kubernetes",
+}
+
+func (r ContainerRuntime) String() string {
+	if name, ok := containerRuntimeNames[r]; ok {
+		return name
+	}
+	return containerRuntimeNames[UnknownContainer]
+}
+
+// Evidence records a single observation made by a probe.
+type Evidence struct {
+	// Indicates is the value the observation indicates, of the type the
+	// probe reports, such as a ContainerRuntime.
+	Indicates fmt.Stringer
+	// Path is the file the observation was made in.
+	Path string
+	// Detail describes what was observed.
+	Detail string
+}
+
+// Container describes the container environment of a process.
+type Container struct {
+	// Runtime is the detected container runtime, or NoContainer.
+	Runtime ContainerRuntime
+	// Evidence holds every observation that indicates a container, in
+	// the order they were made.
+	Evidence []Evidence
+}
+
+// InContainer reports whether a container was detected.
+func (c Container) InContainer() bool {
+	return c.Runtime != NoContainer
+}
+
+// ContainerEnvironment returns the container environment of the host,
+// read relative to HostRoot. Without a host root this describes the
+// container the current process runs in.
+func ContainerEnvironment() (Container, error) {
+	return DetectContainer(HostFS())
+}
+
+// DetectContainer returns the container environment described by the root
+// filesystem fsys, which is expected to include /proc and /run. Kubernetes
+// takes precedence over the runtime that runs the pod's containers;
+// otherwise the runtime is that of the first evidence found.
+func DetectContainer(fsys fs.FS) (Container, error) {
+	var result Container
+	add := func(runtime ContainerRuntime, path, detail string) {
+		result.Evidence = append(result.Evidence, Evidence{
+			Indicates: runtime,
+			Path:      path,
+			Detail:    detail,
+		})
+		if result.Runtime == NoContainer || runtime == KubernetesContainer {
+			result.Runtime = runtime
+		}
+	}
+
+	// systemd and the container managers that follow its container
+	// interface write the manager name here.
+	if contents, ok, err := readProbeFile(fsys, "/run/systemd/container"); err != nil {
+		return Container{}, errors.Trace(err)
+	} else if ok {
+		name := strings.TrimSpace(string(contents))
+		add(containerManagerRuntime(fsys, name), "/run/systemd/container", "container="+name)
+	}
+	if exists, err := probeExists(fsys, "/.dockerenv"); err != nil {
+		return Container{}, errors.Trace(err)
+	} else if exists {
+		add(DockerContainer, "/.dockerenv", "file exists")
+	}
+	if exists, err := probeExists(fsys, "/run/.containerenv"); err != nil {
+		return Container{}, errors.Trace(err)
+	} else if exists {
+		add(PodmanContainer, "/run/.containerenv", "file exists")
+	}
+
+	for _, path := range []string{"/proc/1/environ", "/proc/self/environ"} {
+		contents, ok, err := readProbeFile(fsys, path)
+		if err != nil {
+			return Container{}, errors.Trace(err)
+		} else if !ok {
+			continue
+		}
+		for _, v := range bytes.Split(contents, []byte{0}) {
+			kv := strings.SplitN(string(v), "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "container":
+				add(containerManagerRuntime(fsys, kv[1]), path, string(v))
+			case "KUBERNETES_SERVICE_HOST":
+				add(KubernetesContainer, path, string(v))
+			case "WSL_DISTRO_NAME", "WSL_INTEROP":
+				add(WSLContainer, path, string(v))
+			}
+		}
+	}
+
+	for _, path := range []string{"/proc/1/cgroup", "/proc/self/cgroup"} {
+		contents, ok, err := readProbeFile(fsys, path)
+		if err != nil {
+			return Container{}, errors.Trace(err)
+		} else if !ok {
+			continue
+		}
+		for _, line := range strings.Split(string(contents), "\n") {
+			if runtime := cgroupRuntime(line); runtime != NoContainer {
+				add(runtime, path, strings.TrimSpace(line))
+			}
+		}
+	}
+
+	for _, dir := range kubernetesServiceAccountDirs {
+		if exists, err := probeExists(fsys, dir+"/token"); err != nil {
+			return Container{}, errors.Trace(err)
+		} else if exists {
+			add(KubernetesContainer, dir+"/token", "service account token mounted")
+		}
+	}
+
+	if contents, ok, err := readProbeFile(fsys, "/proc/sys/kernel/osrelease"); err != nil {
+		return Container{}, errors.Trace(err)
+	} else if ok {
+		release := strings.TrimSpace(string(contents))
+		if lower := strings.ToLower(release); strings.Contains(lower, "microsoft") || strings.Contains(lower, "wsl") {
+			add(WSLContainer, "/proc/sys/kernel/osrelease", release)
+		}
+	}
+	if exists, err := probeExists(fsys, "/proc/sys/fs/binfmt_misc/WSLInterop"); err != nil {
+		return Container{}, errors.Trace(err)
+	} else if exists {
+		add(WSLContainer, "/proc/sys/fs/binfmt_misc/WSLInterop", "file exists")
+	}
+
+	return result, nil
+}
+
+// containerManagerRuntime maps the container manager names used by the
+// systemd container interface onto a runtime. LXD uses the name "lxc", and
+// is told apart from LXC by the socket it provides to the container.
+func containerManagerRuntime(fsys fs.FS, name string) ContainerRuntime {
+	switch name {
+	case "lxc", "lxc-libvirt":
+		if exists, _ := probeExists(fsys, "/dev/lxd/sock"); exists {
+			return LXDContainer
+		}
+		return LXCContainer
+	case "docker":
+		return DockerContainer
+	case "podman":
+		return PodmanContainer
+	case "systemd-nspawn":
+		return SystemdNspawnContainer
+	case "wsl":
+		return WSLContainer
+	}
+	return UnknownContainer
+}
+
+// cgroupRuntime returns the runtime indicated by a line of /proc/*/cgroup,
+// or NoContainer.
+func cgroupRuntime(line string) ContainerRuntime {
+	parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+	if len(parts) != 3 {
+		return NoContainer
+	}
+	path := parts[2]
+	switch {
+	case strings.Contains(path, "kubepods"):
+		return KubernetesContainer
+	case strings.Contains(path, "/docker/"), strings.Contains(path, "/docker-"):
+		return DockerContainer
+	case strings.Contains(path, "libpod"):
+		return PodmanContainer
+	case strings.Contains(path, "/lxc/"), strings.Contains(path, "/lxc.payload"):
+		return LXCContainer
+	case strings.Contains(path, "/machine.slice/machine-"):
+		return SystemdNspawnContainer
+	}
+	return NoContainer
+}
+
+// readProbeFile reads the file at the absolute path name from fsys.
+// Files that are missing or cannot be read for lack of permission are
+// reported as not found, as is usual for files below /proc.
+func readProbeFile(fsys fs.FS, name string) ([]byte, bool, error) {
+	contents, err := fs.ReadFile(fsys, fsPath(name))
+	if goos.IsNotExist(err) || goos.IsPermission(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return contents, true, nil
+}
+
+// probeExists reports whether the absolute path name exists in fsys.
+func probeExists(fsys fs.FS, name string) (bool, error) {
+	_, err := fs.Stat(fsys, fsPath(name))
+	if goos.IsNotExist(err) || goos.IsPermission(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
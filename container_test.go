@@ -0,0 +1,134 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type containerSuite struct {
+}
+
+var _ = gc.Suite(&containerSuite{})
+
+var detectContainerTests = []struct {
+	message string
+	files   fstest.MapFS
+	runtime ContainerRuntime
+}{{
+	message: "bare metal",
+	files: fstest.MapFS{
+		"proc/1/cgroup":             {Data: []byte("0::/init.scope\n")},
+		"proc/sys/kernel/osrelease": {Data: []byte("6.5.0-14-generic\n")},
+	},
+	runtime: NoContainer,
+}, {
+	message: "lxd",
+	files: fstest.MapFS{
+		"run/systemd/container": {Data: []byte("lxc\n")},
+		"dev/lxd/sock":          {},
+	},
+	runtime: LXDContainer,
+}, {
+	message: "lxc",
+	files: fstest.MapFS{
+		"run/systemd/container": {Data: []byte("lxc\n")},
+	},
+	runtime: LXCContainer,
+}, {
+	message: "docker",
+	files: fstest.MapFS{
+		".dockerenv": {},
+	},
+	runtime: DockerContainer,
+}, {
+	message: "docker cgroup v1",
+	files: fstest.MapFS{
+		"proc/self/cgroup": {Data: []byte("12:pids:/docker/0123456789abcdef\n1:name=systemd:/docker/0123456789abcdef\n")},
+	},
+	runtime: DockerContainer,
+}, {
+	message: "podman",
+	files: fstest.MapFS{
+		"run/.containerenv": {},
+		"proc/1/environ":    {Data: []byte("PATH=/usr/bin\x00container=podman\x00")},
+	},
+	runtime: PodmanContainer,
+}, {
+	message: "systemd-nspawn",
+	files: fstest.MapFS{
+		"proc/1/environ": {Data: []byte("container=systemd-nspawn\x00TERM=vt220\x00")},
+	},
+	runtime: SystemdNspawnContainer,
+}, {
+	message: "wsl",
+	files: fstest.MapFS{
+		"proc/sys/kernel/osrelease": {Data: []byte("5.15.133.1-microsoft-standard-WSL2\n")},
+	},
+	runtime: WSLContainer,
+}, {
+	message: "kubernetes under docker",
+	files: fstest.MapFS{
+		".dockerenv":    {},
+		"proc/1/cgroup": {Data: []byte("0::/kubepods/besteffort/pod1234/abcdef\n")},
+		"var/run/secrets/kubernetes.io/serviceaccount/token": {Data: []byte("token")},
+	},
+	runtime: KubernetesContainer,
+}, {
+	message: "unknown manager",
+	files: fstest.MapFS{
+		"run/systemd/container": {Data: []byte("acme\n")},
+	},
+	runtime: UnknownContainer,
+}}
+
+func (s *containerSuite) TestDetectContainer(c *gc.C) {
+	for i, t := range detectContainerTests {
+		c.Logf("test %d: %s", i, t.message)
+		result, err := DetectContainer(t.files)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Runtime, gc.Equals, t.runtime)
+		c.Check(result.InContainer(), gc.Equals, t.runtime != NoContainer)
+		c.Check(result.Evidence == nil, gc.Equals, t.runtime == NoContainer)
+	}
+}
+
+func (s *containerSuite) TestDetectContainerEvidence(c *gc.C) {
+	result, err := DetectContainer(fstest.MapFS{
+		".dockerenv":     {},
+		"proc/1/environ": {Data: []byte("HOME=/root\x00KUBERNETES_SERVICE_HOST=10.152.183.1\x00")},
+		"run/secrets/kubernetes.io/serviceaccount/token": {Data: []byte("token")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Runtime, gc.Equals, KubernetesContainer)
+	c.Check(result.Evidence, jc.DeepEquals, []Evidence{{
+		Indicates: DockerContainer,
+		Path:      "/.dockerenv",
+		Detail:    "file exists",
+	}, {
+		Indicates: KubernetesContainer,
+		Path:      "/proc/1/environ",
+		Detail:    "KUBERNETES_SERVICE_HOST=10.152.183.1",
+	}, {
+		Indicates: KubernetesContainer,
+		Path:      "/run/secrets/kubernetes.io/serviceaccount/token",
+		Detail:    "service account token mounted",
+	}})
+}
+
+func (s *containerSuite) TestContainerRuntimeString(c *gc.C) {
+	c.Check(NoContainer.String(), gc.Equals, "none")
+	c.Check(SystemdNspawnContainer.String(), gc.Equals, "systemd-nspawn")
+	c.Check(KubernetesContainer.String(), gc.Equals, "kubernetes")
+	c.Check(ContainerRuntime(99).String(), gc.Equals, "unknown")
+}
+
+func (s *containerSuite) TestContainerEnvironment(c *gc.C) {
+	result, err := ContainerEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.InContainer(), gc.Equals, len(result.Evidence) > 0)
+}
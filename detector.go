@@ -0,0 +1,200 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// DetectorConfig holds the configuration of a Detector.
+type DetectorConfig struct {
+	// FS is the root filesystem that is described. If nil, the host
+	// filesystem returned by HostFS is used, and everything cached is
+	// detected again whenever HostRoot changes.
+	FS fs.FS
+
+	// Clock is used to expire cached results. If nil, the wall clock is
+	// used.
+	Clock clock.Clock
+
+	// MaxAge is how long a result is cached for. If zero, results are
+	// cached until Refresh is called.
+	MaxAge time.Duration
+}
+
+// Detector detects and caches facts about a root filesystem. It is safe
+// for concurrent use.
+type Detector struct {
+	fsys   fs.FS
+	clock  clock.Clock
+	maxAge time.Duration
+
+	mu sync.Mutex
+	// root is the host root the cache was filled from, when fsys is nil.
+	root string
+	// generation is incremented whenever the cache is cleared, so that
+	// detections started beforehand are not cached.
+	generation int
+	entries    map[string]detectorEntry
+}
+
+type detectorEntry struct {
+	value    interface{}
+	err      error
+	detected time.Time
+}
+
+// NewDetector returns a Detector with the given configuration.
+func NewDetector(config DetectorConfig) *Detector {
+	d := &Detector{
+		fsys:    config.FS,
+		clock:   config.Clock,
+		maxAge:  config.MaxAge,
+		entries: make(map[string]detectorEntry),
+	}
+	if d.clock == nil {
+		d.clock = clock.WallClock
+	}
+	return d
+}
+
+// defaultDetector describes the host, and is used by the package level
+// functions such as HostOS and HostDistribution.
+var defaultDetector = NewDetector(DetectorConfig{})
+
+// DefaultDetector returns the Detector that describes the host, which
+// the package level functions such as HostOS and HostDistribution use.
+func DefaultDetector() *Detector {
+	return defaultDetector
+}
+
+// FS returns the root filesystem described by the detector.
+func (d *Detector) FS() fs.FS {
+	if d.fsys != nil {
+		return d.fsys
+	}
+	return HostFS()
+}
+
+// Refresh discards every cached result, so that each is detected again
+// when next requested.
+func (d *Detector) Refresh() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clear()
+}
+
+func (d *Detector) clear() {
+	d.entries = make(map[string]detectorEntry)
+	d.generation++
+}
+
+// cached returns the result cached under key, calling detect with the
+// detector's filesystem to fill the cache if there is no result or it has
+// expired. Errors are cached as well as values. The cache is not locked
+// while detect runs, so detect may use the detector itself; concurrent
+// callers that miss the cache may each call detect.
+func (d *Detector) cached(key string, detect func(fsys fs.FS) (interface{}, error)) (interface{}, error) {
+	d.mu.Lock()
+	if d.fsys == nil {
+		if root := HostRoot(); root != d.root {
+			d.clear()
+			d.root = root
+		}
+	}
+	entry, ok := d.entries[key]
+	if ok && (d.maxAge == 0 || d.clock.Now().Sub(entry.detected) < d.maxAge) {
+		d.mu.Unlock()
+		return entry.value, entry.err
+	}
+	generation := d.generation
+	d.mu.Unlock()
+
+	value, err := detect(d.FS())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.generation == generation {
+		d.entries[key] = detectorEntry{
+			value:    value,
+			err:      err,
+			detected: d.clock.Now(),
+		}
+	}
+	return value, err
+}
+
+// Release returns the release data of the filesystem, read from the first
+// usable file of ReleaseFiles. The result is shared and must not be
+// modified; a new result is returned each time the release data is read
+// again, such as after Refresh.
+func (d *Detector) Release() (*OSRelease, error) {
+	v, err := d.cached("release", func(fsys fs.FS) (interface{}, error) {
+		return ReadReleaseFS(fsys, ReleaseFiles())
+	})
+	return v.(*OSRelease), err
+}
+
+// Distribution returns the distribution of the filesystem. The errors are
+// those described by DetectHostOS.
+func (d *Detector) Distribution() (Distribution, error) {
+	v, err := d.cached("distribution", func(fs.FS) (interface{}, error) {
+		return d.detectDistribution()
+	})
+	return v.(Distribution), err
+}
+
+// OS returns the OS type of the filesystem. The errors are those described
+// by DetectHostOS.
+func (d *Detector) OS() (OSType, error) {
+	return d.detectOS()
+}
+
+// Container returns the container environment of the filesystem.
+func (d *Detector) Container() (Container, error) {
+	v, err := d.cached("container", func(fsys fs.FS) (interface{}, error) {
+		container, err := DetectContainer(fsys)
+		return container, errors.Trace(err)
+	})
+	return v.(Container), err
+}
+
+// Virtualization returns the hypervisor of the filesystem.
+func (d *Detector) Virtualization() (Virtualization, error) {
+	v, err := d.cached("virtualization", func(fsys fs.FS) (interface{}, error) {
+		virt, err := DetectVirtualization(fsys)
+		return virt, errors.Trace(err)
+	})
+	return v.(Virtualization), err
+}
+
+// inKubernetesPod is like InKubernetesPod, but describes the detector's
+// filesystem.
+func (d *Detector) inKubernetesPod() bool {
+	if d.fsys == nil {
+		return InKubernetesPod()
+	}
+	return kubernetesTokenMounted(d.fsys)
+}
+
+func hostOS() OSType {
+	osType, err := detectHostOS()
+	if err != nil && !IsUnrecognisedDistroError(err) {
+		panic("unable to determine host OS: " + err.Error())
+	}
+	return osType
+}
+
+func detectHostOS() (OSType, error) {
+	return defaultDetector.OS()
+}
+
+func hostDistribution() (Distribution, error) {
+	return defaultDetector.Distribution()
+}
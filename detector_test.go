@@ -0,0 +1,192 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"runtime"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type detectorSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&detectorSuite{})
+
+func (s *detectorSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.AddCleanup(func(*gc.C) { SetKubernetesDetection(false) })
+}
+
+func releaseFS(id, versionID string) fstest.MapFS {
+	return fstest.MapFS{
+		"etc/os-release": {Data: []byte("ID=" + id + "\nVERSION_ID=" + versionID + "\n")},
+	}
+}
+
+func (s *detectorSuite) TestRelease(c *gc.C) {
+	d := NewDetector(DetectorConfig{FS: releaseFS("ubuntu", "22.04")})
+	release, err := d.Release()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "ubuntu")
+	c.Check(release.VersionID, gc.Equals, "22.04")
+	c.Check(release.Source, gc.Equals, "/etc/os-release")
+}
+
+func (s *detectorSuite) TestReleaseNotFound(c *gc.C) {
+	d := NewDetector(DetectorConfig{FS: fstest.MapFS{}})
+	_, err := d.Release()
+	c.Check(err, jc.Satisfies, IsReleaseNotFoundError)
+}
+
+func (s *detectorSuite) TestDistribution(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("distributions are only read from the filesystem on Linux")
+	}
+	d := NewDetector(DetectorConfig{FS: releaseFS("rocky", "9.3")})
+	distro, err := d.Distribution()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(distro.OSType, gc.Equals, Rocky)
+	osType, err := d.OS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Rocky)
+
+	d = NewDetector(DetectorConfig{FS: releaseFS("plan9", "4")})
+	osType, err = d.OS()
+	c.Check(err, jc.Satisfies, IsUnrecognisedDistroError)
+	c.Check(osType, gc.Equals, GenericLinux)
+}
+
+func (s *detectorSuite) TestOSKubernetes(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("Kubernetes is only detected on Linux")
+	}
+	fsys := releaseFS("ubuntu", "22.04")
+	fsys["run/secrets/kubernetes.io/serviceaccount/token"] = &fstest.MapFile{Data: []byte("token")}
+	d := NewDetector(DetectorConfig{FS: fsys})
+
+	osType, err := d.OS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Ubuntu)
+
+	SetKubernetesDetection(true)
+	osType, err = d.OS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Kubernetes)
+}
+
+func (s *detectorSuite) TestCachedUntilRefresh(c *gc.C) {
+	fsys := releaseFS("ubuntu", "22.04")
+	d := NewDetector(DetectorConfig{FS: fsys})
+	release, err := d.Release()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.VersionID, gc.Equals, "22.04")
+
+	fsys["etc/os-release"].Data = []byte("ID=ubuntu\nVERSION_ID=24.04\n")
+	release, err = d.Release()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.VersionID, gc.Equals, "22.04")
+
+	d.Refresh()
+	release, err = d.Release()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.VersionID, gc.Equals, "24.04")
+}
+
+func (s *detectorSuite) TestMaxAge(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC))
+	d := NewDetector(DetectorConfig{
+		FS:     fstest.MapFS{},
+		Clock:  clock,
+		MaxAge: time.Minute,
+	})
+	calls := 0
+	detect := func(fs.FS) (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	v, err := d.cached("test", detect)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, 1)
+
+	clock.Advance(59 * time.Second)
+	v, err = d.cached("test", detect)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, 1)
+
+	clock.Advance(time.Second)
+	v, err = d.cached("test", detect)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, 2)
+}
+
+func (s *detectorSuite) TestCachedErrors(c *gc.C) {
+	d := NewDetector(DetectorConfig{FS: fstest.MapFS{}})
+	calls := 0
+	detect := func(fs.FS) (interface{}, error) {
+		calls++
+		return nil, errors.New("boom")
+	}
+	_, err := d.cached("test", detect)
+	c.Check(err, gc.ErrorMatches, "boom")
+	_, err = d.cached("test", detect)
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(calls, gc.Equals, 1)
+}
+
+func (s *detectorSuite) TestCachedUsesFS(c *gc.C) {
+	fsys := fstest.MapFS{}
+	d := NewDetector(DetectorConfig{FS: fsys})
+	c.Check(d.FS(), jc.DeepEquals, fsys)
+	_, err := d.cached("test", func(got fs.FS) (interface{}, error) {
+		c.Check(got, jc.DeepEquals, fsys)
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *detectorSuite) TestFollowsHostRoot(c *gc.C) {
+	d := NewDetector(DetectorConfig{})
+	root := c.MkDir()
+	hosttest.WriteFile(c, root, "etc/os-release", "ID=debian\nVERSION_ID=12\n")
+	s.PatchEnvironment(HostRootEnvVar, root)
+
+	release, err := d.Release()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "debian")
+
+	s.PatchEnvironment(HostRootEnvVar, c.MkDir())
+	_, err = d.Release()
+	c.Check(err, jc.Satisfies, IsReleaseNotFoundError)
+}
+
+func (s *detectorSuite) TestConcurrentUse(c *gc.C) {
+	d := NewDetector(DetectorConfig{FS: releaseFS("ubuntu", "22.04")})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := d.Release()
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(release.ID, gc.Equals, "ubuntu")
+			_, err = d.Container()
+			c.Check(err, jc.ErrorIsNil)
+			d.Refresh()
+		}()
+	}
+	wg.Wait()
+}
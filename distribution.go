@@ -0,0 +1,138 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Distribution describes the operating system identified from os-release
+// data, along with the distribution it was derived from.
+type Distribution struct {
+	// OSType is the OS type that the distribution resolved to.
+	OSType OSType
+	// ID is the os-release ID of the distribution itself, e.g. "rocky".
+	ID string
+	// MatchedID is the entry, taken from either ID or ID_LIKE, that
+	// was recognised. It is empty if nothing matched.
+	MatchedID string
+	// Source is the path of the release file the distribution was
+	// identified from.
+	Source string
+	// Variant is the os-release VARIANT_ID, e.g. "coreos".
+	Variant string
+	// Immutable indicates an image-based distribution whose root
+	// filesystem is read-only, such as Ubuntu Core or Flatcar.
+	Immutable bool
+}
+
+// DistributionFromOSRelease resolves the OS type described by release.
+// The ID is tried first, followed by each entry of ID_LIKE in the order
+// declared. If none is recognised the distribution is GenericLinux.
+//
+// The series of Ubuntu, CentOS and openSUSE is resolved from the release
+// data, so a derivative of one of them, recognised through ID_LIKE, is
+// GenericLinux unless it names the release it is based on: UBUNTU_CODENAME
+// for Ubuntu, or VERSION_ID for the others.
+func DistributionFromOSRelease(release *OSRelease) Distribution {
+	distro := Distribution{
+		OSType:    GenericLinux,
+		ID:        release.ID,
+		Source:    release.Source,
+		Variant:   release.VariantID,
+		Immutable: IsImmutable(release),
+	}
+	for i, id := range append([]string{release.ID}, release.IDLike...) {
+		id = strings.ToLower(id)
+		if osType, ok := osTypeForOSReleaseID(id); ok {
+			if i == 0 || namesBaseRelease(osType, release) {
+				distro.OSType = osType
+			}
+			distro.MatchedID = id
+			break
+		}
+	}
+	return distro
+}
+
+// namesBaseRelease reports whether the release data of a derivative of
+// osType names the release of osType it is based on, where that is needed
+// to resolve its series.
+func namesBaseRelease(osType OSType, release *OSRelease) bool {
+	switch osType {
+	case Ubuntu:
+		return release.UbuntuCodename != ""
+	case CentOS, OpenSUSE:
+		return release.VersionID != ""
+	}
+	return true
+}
+
+// immutableIDs are the os-release ID, ID_LIKE, VARIANT_ID and IMAGE_ID
+// values that identify image-based distributions with a read-only root
+// filesystem.
+var immutableIDs = map[string]bool{
+	"ubuntu-core":      true,
+	"coreos":           true,
+	"fedora-coreos":    true,
+	"rhcos":            true,
+	"flatcar":          true,
+	"silverblue":       true,
+	"kinoite":          true,
+	"microos":          true,
+	"opensuse-microos": true,
+	"bottlerocket":     true,
+	"talos":            true,
+}
+
+// immutableVariants are the VARIANT_ID values that identify image-based
+// editions of a distribution, keyed by its ID, for variant names that are
+// too generic to be recognised on their own.
+var immutableVariants = map[string]map[string]bool{
+	"fedora": {"iot": true},
+}
+
+// IsImmutable reports whether release describes an image-based
+// distribution whose root filesystem is read-only, such as Ubuntu Core,
+// Fedora CoreOS or Flatcar. These are identified by their ID, ID_LIKE,
+// VARIANT_ID or IMAGE_ID.
+func IsImmutable(release *OSRelease) bool {
+	ids := append([]string{release.ID, release.VariantID, release.ImageID}, release.IDLike...)
+	for _, id := range ids {
+		if immutableIDs[strings.ToLower(id)] {
+			return true
+		}
+	}
+	return immutableVariants[strings.ToLower(release.ID)][strings.ToLower(release.VariantID)]
+}
+
+// DetectOS returns the OS type of the Linux root filesystem fsys, such as a
+// mounted machine image or chroot. The errors are those described by
+// DetectHostOS.
+func DetectOS(fsys fs.FS) (OSType, error) {
+	d, err := DetectDistribution(fsys)
+	return d.OSType, err
+}
+
+// DetectDistribution identifies the distribution installed in the Linux
+// root filesystem fsys from the first usable file in ReleaseFiles. The
+// errors are those described by DetectHostOS.
+func DetectDistribution(fsys fs.FS) (Distribution, error) {
+	release, err := ReadReleaseFS(fsys, ReleaseFiles())
+	if err != nil {
+		return Distribution{OSType: Unknown}, err
+	}
+	return distributionFromRelease(release)
+}
+
+// distributionFromRelease resolves the distribution described by release,
+// returning an error if it is not recognised.
+func distributionFromRelease(release *OSRelease) (Distribution, error) {
+	d := DistributionFromOSRelease(release)
+	if d.MatchedID == "" {
+		return d, unrecognisedDistroError(d.ID)
+	}
+	return d, nil
+}
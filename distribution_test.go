@@ -0,0 +1,257 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"strings"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type distributionSuite struct {
+}
+
+var _ = gc.Suite(&distributionSuite{})
+
+func (s *distributionSuite) TestDistributionFromOSRelease(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		release  string
+		expected Distribution
+	}{{
+		message:  "ubuntu",
+		release:  "ID=ubuntu\nID_LIKE=debian",
+		expected: Distribution{OSType: Ubuntu, ID: "ubuntu", MatchedID: "ubuntu"},
+	}, {
+		message:  "centos",
+		release:  `ID="centos"` + "\n" + `ID_LIKE="rhel fedora"`,
+		expected: Distribution{OSType: CentOS, ID: "centos", MatchedID: "centos"},
+	}, {
+		message:  "rocky",
+		release:  `ID="rocky"` + "\n" + `ID_LIKE="rhel centos fedora"`,
+		expected: Distribution{OSType: Rocky, ID: "rocky", MatchedID: "rocky"},
+	}, {
+		message:  "almalinux",
+		release:  `ID="almalinux"` + "\n" + `ID_LIKE="rhel centos fedora"`,
+		expected: Distribution{OSType: AlmaLinux, ID: "almalinux", MatchedID: "almalinux"},
+	}, {
+		message:  "rhel",
+		release:  `ID="rhel"` + "\n" + `ID_LIKE="fedora"`,
+		expected: Distribution{OSType: RHEL, ID: "rhel", MatchedID: "rhel"},
+	}, {
+		message:  "opensuse leap",
+		release:  `ID="opensuse-leap"` + "\n" + `ID_LIKE="suse opensuse"`,
+		expected: Distribution{OSType: OpenSUSE, ID: "opensuse-leap", MatchedID: "opensuse-leap"},
+	}, {
+		message:  "linux mint",
+		release:  "ID=linuxmint\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=21.2\nUBUNTU_CODENAME=jammy",
+		expected: Distribution{OSType: Ubuntu, ID: "linuxmint", MatchedID: "ubuntu"},
+	}, {
+		message:  "ubuntu derivative without UBUNTU_CODENAME",
+		release:  "ID=linuxmint\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=21.2",
+		expected: Distribution{OSType: GenericLinux, ID: "linuxmint", MatchedID: "ubuntu"},
+	}, {
+		message:  "ID_LIKE in declared order",
+		release:  "ID=custom\nID_LIKE=\"unknown opensuse ubuntu\"\nVERSION_ID=42.3",
+		expected: Distribution{OSType: OpenSUSE, ID: "custom", MatchedID: "opensuse"},
+	}, {
+		message:  "case insensitive",
+		release:  "ID=Ubuntu",
+		expected: Distribution{OSType: Ubuntu, ID: "Ubuntu", MatchedID: "ubuntu"},
+	}, {
+		message:  "debian",
+		release:  "ID=debian",
+		expected: Distribution{OSType: Debian, ID: "debian", MatchedID: "debian"},
+	}, {
+		message:  "fedora",
+		release:  "ID=fedora",
+		expected: Distribution{OSType: Fedora, ID: "fedora", MatchedID: "fedora"},
+	}, {
+		message:  "amazon linux",
+		release:  `ID="amzn"` + "\n" + `ID_LIKE="centos rhel fedora"`,
+		expected: Distribution{OSType: AmazonLinux, ID: "amzn", MatchedID: "amzn"},
+	}, {
+		message:  "alpine",
+		release:  "ID=alpine",
+		expected: Distribution{OSType: Alpine, ID: "alpine", MatchedID: "alpine"},
+	}, {
+		message:  "arch",
+		release:  "ID=arch",
+		expected: Distribution{OSType: Arch, ID: "arch", MatchedID: "arch"},
+	}, {
+		message:  "manjaro",
+		release:  "ID=manjaro\nID_LIKE=arch",
+		expected: Distribution{OSType: Arch, ID: "manjaro", MatchedID: "arch"},
+	}, {
+		message:  "sles",
+		release:  `ID="sles"` + "\n" + `ID_LIKE="suse"`,
+		expected: Distribution{OSType: SLES, ID: "sles", MatchedID: "sles"},
+	}, {
+		message:  "unknown suse derivative",
+		release:  `ID="suse-custom"` + "\n" + `ID_LIKE="suse"` + "\n" + `VERSION_ID="42.3"`,
+		expected: Distribution{OSType: OpenSUSE, ID: "suse-custom", MatchedID: "suse"},
+	}, {
+		message:  "oracle linux",
+		release:  `ID="ol"` + "\n" + `ID_LIKE="fedora"`,
+		expected: Distribution{OSType: Fedora, ID: "ol", MatchedID: "fedora"},
+	}, {
+		message:  "rhel clone",
+		release:  `ID="eurolinux"` + "\n" + `ID_LIKE="rhel centos fedora"`,
+		expected: Distribution{OSType: RHEL, ID: "eurolinux", MatchedID: "rhel"},
+	}, {
+		message:  "ubuntu core",
+		release:  "ID=ubuntu-core\nVERSION_ID=22",
+		expected: Distribution{OSType: Ubuntu, ID: "ubuntu-core", MatchedID: "ubuntu-core", Immutable: true},
+	}, {
+		message:  "fedora coreos",
+		release:  "ID=fedora\nVARIANT_ID=coreos",
+		expected: Distribution{OSType: Fedora, ID: "fedora", MatchedID: "fedora", Variant: "coreos", Immutable: true},
+	}, {
+		message:  "fedora server",
+		release:  "ID=fedora\nVARIANT_ID=server",
+		expected: Distribution{OSType: Fedora, ID: "fedora", MatchedID: "fedora", Variant: "server"},
+	}, {
+		message:  "flatcar",
+		release:  "ID=flatcar\nID_LIKE=coreos",
+		expected: Distribution{OSType: GenericLinux, ID: "flatcar", Immutable: true},
+	}, {
+		message:  "unrecognised",
+		release:  "ID=nixos",
+		expected: Distribution{OSType: GenericLinux, ID: "nixos"},
+	}, {
+		message:  "unrecognised ID_LIKE",
+		release:  "ID=gentoo\nID_LIKE=funtoo",
+		expected: Distribution{OSType: GenericLinux, ID: "gentoo"},
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		release, err := ParseOSRelease(strings.NewReader(test.release))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(DistributionFromOSRelease(release), jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *distributionSuite) TestDetectDistribution(c *gc.C) {
+	fsys := fstest.MapFS{
+		"etc/os-release": {Data: []byte(`NAME="Rocky Linux"
+VERSION="9.3 (Blue Onyx)"
+ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="9.3"
+`)},
+	}
+	distro, err := DetectDistribution(fsys)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(distro, jc.DeepEquals, Distribution{
+		OSType:    Rocky,
+		ID:        "rocky",
+		MatchedID: "rocky",
+		Source:    "/etc/os-release",
+	})
+
+	osType, err := DetectOS(fsys)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(osType, gc.Equals, Rocky)
+}
+
+func (s *distributionSuite) TestDetectDistributionFallback(c *gc.C) {
+	for i, test := range []struct {
+		message string
+		fsys    fstest.MapFS
+		osType  OSType
+		source  string
+	}{{
+		message: "usr lib os-release",
+		fsys: fstest.MapFS{
+			"usr/lib/os-release": {Data: []byte("ID=ubuntu\nVERSION_ID=22.04\n")},
+			"etc/debian_version": {Data: []byte("bookworm/sid\n")},
+		},
+		osType: Ubuntu,
+		source: "/usr/lib/os-release",
+	}, {
+		message: "lsb-release",
+		fsys: fstest.MapFS{
+			"etc/lsb-release":    {Data: []byte("DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=22.04\n")},
+			"etc/debian_version": {Data: []byte("bookworm/sid\n")},
+		},
+		osType: Ubuntu,
+		source: "/etc/lsb-release",
+	}, {
+		message: "centos-release",
+		fsys: fstest.MapFS{
+			"etc/centos-release": {Data: []byte("CentOS Linux release 7.9.2009 (Core)\n")},
+		},
+		osType: CentOS,
+		source: "/etc/centos-release",
+	}, {
+		message: "SuSE-release",
+		fsys: fstest.MapFS{
+			"etc/SuSE-release": {Data: []byte("SUSE Linux Enterprise Server 12 (x86_64)\nVERSION = 12\n")},
+		},
+		osType: SLES,
+		source: "/etc/SuSE-release",
+	}, {
+		message: "debian_version",
+		fsys: fstest.MapFS{
+			"etc/debian_version": {Data: []byte("12.4\n")},
+		},
+		osType: Debian,
+		source: "/etc/debian_version",
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		distro, err := DetectDistribution(test.fsys)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(distro.OSType, gc.Equals, test.osType)
+		c.Check(distro.Source, gc.Equals, test.source)
+	}
+}
+
+func (s *distributionSuite) TestDetectDistributionMissingID(c *gc.C) {
+	distro, err := DetectDistribution(fstest.MapFS{
+		"etc/os-release": {Data: []byte("NAME=Linux\n")},
+	})
+	c.Assert(err, gc.ErrorMatches, "OS release file is missing ID")
+	c.Assert(err, jc.Satisfies, IsMissingIDError)
+	c.Assert(distro.OSType, gc.Equals, Unknown)
+}
+
+func (s *distributionSuite) TestDetectDistributionMissingFile(c *gc.C) {
+	distro, err := DetectDistribution(fstest.MapFS{})
+	c.Assert(err, gc.ErrorMatches, `release file not found \(tried /etc/os-release, .*\)`)
+	c.Assert(err, jc.Satisfies, IsReleaseNotFoundError)
+	c.Assert(distro.OSType, gc.Equals, Unknown)
+}
+
+func (s *distributionSuite) TestDetectDistributionUnrecognised(c *gc.C) {
+	distro, err := DetectDistribution(fstest.MapFS{
+		"etc/os-release": {Data: []byte("ID=nixos\n")},
+	})
+	c.Assert(err, gc.ErrorMatches, `unrecognised Linux distribution: "nixos"`)
+	c.Assert(err, jc.Satisfies, IsUnrecognisedDistroError)
+	c.Assert(distro.OSType, gc.Equals, GenericLinux)
+	c.Assert(distro.ID, gc.Equals, "nixos")
+}
+
+func (s *distributionSuite) TestIsImmutable(c *gc.C) {
+	for i, test := range []struct {
+		release   string
+		immutable bool
+	}{
+		{release: "ID=ubuntu-core", immutable: true},
+		{release: "ID=ubuntu\nVERSION_ID=22.04", immutable: false},
+		{release: "ID=rhcos\nID_LIKE=\"rhel fedora\"", immutable: true},
+		{release: "ID=fedora\nVARIANT_ID=silverblue", immutable: true},
+		{release: "ID=opensuse-microos\nID_LIKE=\"suse opensuse\"", immutable: true},
+		{release: "ID=custom\nIMAGE_ID=flatcar", immutable: true},
+		{release: "ID=fedora\nVARIANT_ID=iot", immutable: true},
+		{release: "ID=debian\nVARIANT_ID=iot", immutable: false},
+		{release: "ID=debian", immutable: false},
+	} {
+		c.Logf("test %d: %q", i, test.release)
+		release, err := ParseOSRelease(strings.NewReader(test.release))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(IsImmutable(release), gc.Equals, test.immutable)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// dmiDir holds the DMI (SMBIOS) identification strings of a machine.
+const dmiDir = "/sys/class/dmi/id"
+
+// dmiFiles are the files in dmiDir that DMI rules may match.
+var dmiFiles = []string{
+	"sys_vendor",
+	"product_name",
+	"bios_vendor",
+	"bios_version",
+	"chassis_asset_tag",
+	"board_vendor",
+	"board_name",
+}
+
+// dmiRule matches the DMI strings of a machine. A rule matches if every
+// one of its files contains the given string.
+type dmiRule struct {
+	match map[string]string
+	// indicates is the value a match is evidence of.
+	indicates fmt.Stringer
+}
+
+// readDMI returns the contents of the files in dmiFiles that exist.
+func readDMI(fsys fs.FS) (map[string]string, error) {
+	dmi := make(map[string]string)
+	for _, name := range dmiFiles {
+		contents, ok, err := readProbeFile(fsys, dmiDir+"/"+name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if ok {
+			dmi[name] = strings.TrimSpace(string(contents))
+		}
+	}
+	return dmi, nil
+}
+
+// matchDMI returns evidence for each file matched by each rule that
+// matches dmi, in the order of rules and then of dmiFiles.
+func matchDMI(dmi map[string]string, rules []dmiRule) []Evidence {
+	var evidence []Evidence
+	for _, rule := range rules {
+		if !rule.matches(dmi) {
+			continue
+		}
+		for _, name := range dmiFiles {
+			if _, ok := rule.match[name]; ok {
+				evidence = append(evidence, Evidence{
+					Indicates: rule.indicates,
+					Path:      dmiDir + "/" + name,
+					Detail:    dmi[name],
+				})
+			}
+		}
+	}
+	return evidence
+}
+
+func (r dmiRule) matches(dmi map[string]string) bool {
+	for name, s := range r.match {
+		if !strings.Contains(dmi[name], s) {
+			return false
+		}
+	}
+	return true
+}
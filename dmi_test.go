@@ -0,0 +1,51 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type dmiSuite struct {
+}
+
+var _ = gc.Suite(&dmiSuite{})
+
+func (s *dmiSuite) TestReadDMI(c *gc.C) {
+	dmi, err := readDMI(fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":  {Data: []byte("QEMU\n")},
+		"sys/class/dmi/id/board_asset": {Data: []byte("ignored\n")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dmi, jc.DeepEquals, map[string]string{"sys_vendor": "QEMU"})
+}
+
+func (s *dmiSuite) TestMatchDMI(c *gc.C) {
+	rules := []dmiRule{
+		{match: map[string]string{"sys_vendor": "Xen"}, indicates: XenHypervisor},
+		{match: map[string]string{
+			"product_name": "Virtual Machine",
+			"sys_vendor":   "Microsoft",
+		}, indicates: HyperVHypervisor},
+		{match: map[string]string{"bios_vendor": "Microsoft"}, indicates: HyperVHypervisor},
+	}
+	evidence := matchDMI(map[string]string{
+		"sys_vendor":   "Microsoft Corporation",
+		"product_name": "Virtual Machine",
+	}, rules)
+	c.Check(evidence, jc.DeepEquals, []Evidence{{
+		Indicates: HyperVHypervisor,
+		Path:      "/sys/class/dmi/id/sys_vendor",
+		Detail:    "Microsoft Corporation",
+	}, {
+		Indicates: HyperVHypervisor,
+		Path:      "/sys/class/dmi/id/product_name",
+		Detail:    "Virtual Machine",
+	}})
+
+	c.Check(matchDMI(map[string]string{"sys_vendor": "Dell Inc."}, rules), gc.HasLen, 0)
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+type releaseNotFoundError []string
+
+func (e releaseNotFoundError) Error() string {
+	return "release file not found (tried " + strings.Join(e, ", ") + ")"
+}
+
+// IsReleaseNotFoundError returns true if err is caused by none of the
+// release files that identify a Linux distribution existing.
+func IsReleaseNotFoundError(err error) bool {
+	_, ok := errors.Cause(err).(releaseNotFoundError)
+	return ok
+}
+
+type missingIDError struct{}
+
+func (e missingIDError) Error() string {
+	return "OS release file is missing ID"
+}
+
+// IsMissingIDError returns true if err is caused by a release file that
+// does not define an ID.
+func IsMissingIDError(err error) bool {
+	_, ok := errors.Cause(err).(missingIDError)
+	return ok
+}
+
+type unrecognisedDistroError string
+
+func (e unrecognisedDistroError) Error() string {
+	return `unrecognised Linux distribution: "` + string(e) + `"`
+}
+
+// IsUnrecognisedDistroError returns true if err is caused by a Linux
+// distribution that does not match any known OS type.
+func IsUnrecognisedDistroError(err error) bool {
+	_, ok := errors.Cause(err).(unrecognisedDistroError)
+	return ok
+}
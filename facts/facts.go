@@ -0,0 +1,132 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package facts collects what is known about a host into a single
+// snapshot that can be serialised and shipped elsewhere.
+package facts
+
+import (
+	"runtime"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/arch"
+	"github.com/juju/os/v2/series"
+)
+
+// Facts is a snapshot of what is known about a host. The JSON and YAML
+// names of the fields are stable. A field that could not be determined is
+// left empty, and the reason recorded in Errors under the field's name.
+type Facts struct {
+	// OSType is the name of the OS type, as returned by os.HostOS.
+	OSType string `json:"os-type" yaml:"os-type"`
+	// Distribution is the os-release ID of the Linux distribution.
+	Distribution string `json:"distribution,omitempty" yaml:"distribution,omitempty"`
+	// Series is the series, as returned by series.HostSeries.
+	Series string `json:"series,omitempty" yaml:"series,omitempty"`
+	// Version is the os-release VERSION_ID of the Linux distribution.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Codename is the codename of the Linux distribution release.
+	Codename string `json:"codename,omitempty" yaml:"codename,omitempty"`
+	// Immutable indicates an image-based distribution whose root
+	// filesystem is read-only, such as Ubuntu Core.
+	Immutable bool `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+	// ReleaseFile is the file the release was read from.
+	ReleaseFile string `json:"release-file,omitempty" yaml:"release-file,omitempty"`
+	// Arch is the architecture of the userland.
+	Arch string `json:"arch,omitempty" yaml:"arch,omitempty"`
+	// KernelArch is the architecture of the running kernel.
+	KernelArch string `json:"kernel-arch,omitempty" yaml:"kernel-arch,omitempty"`
+	// Kernel is the release of the running Linux kernel.
+	Kernel string `json:"kernel,omitempty" yaml:"kernel,omitempty"`
+	// Container is the container runtime, or "none".
+	Container string `json:"container,omitempty" yaml:"container,omitempty"`
+	// Virtualization is the hypervisor, or "none".
+	Virtualization string `json:"virtualization,omitempty" yaml:"virtualization,omitempty"`
+	// CloudProvider is the cloud the host runs in, or "unknown".
+	CloudProvider string `json:"cloud-provider,omitempty" yaml:"cloud-provider,omitempty"`
+	// InitSystem is the init system the Linux host booted with.
+	InitSystem string `json:"init-system,omitempty" yaml:"init-system,omitempty"`
+
+	// Errors holds the reason each field that could not be determined
+	// was left empty, keyed by the field's JSON name. Errors that leave
+	// a usable value, such as an unrecognised distribution, are also
+	// recorded.
+	Errors map[string]string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// HostFacts returns a snapshot of the host, read relative to os.HostRoot.
+// Every probe is attempted, so that one that fails does not prevent the
+// others from being reported.
+func HostFacts() Facts {
+	var f Facts
+	recordErr := func(field string, err error) {
+		if err == nil {
+			return
+		}
+		if f.Errors == nil {
+			f.Errors = make(map[string]string)
+		}
+		f.Errors[field] = err.Error()
+	}
+
+	osType, err := os.DetectHostOS()
+	f.OSType = osType.String()
+	recordErr("os-type", err)
+
+	f.Series, err = series.HostSeries()
+	recordErr("series", err)
+
+	if runtime.GOOS == "linux" {
+		release, err := os.DefaultDetector().Release()
+		if err == nil {
+			f.Distribution = release.ID
+			f.Version = release.VersionID
+			f.Codename = release.VersionCodename
+			if f.Codename == "" {
+				f.Codename = release.UbuntuCodename
+			}
+			f.Immutable = os.IsImmutable(release)
+			f.ReleaseFile = release.Source
+		}
+		recordErr("version", err)
+
+		kernel, err := os.HostKernelVersion()
+		if err == nil {
+			f.Kernel = kernel.Raw
+		}
+		recordErr("kernel", err)
+
+		initSystem, err := os.HostInitSystem()
+		if err == nil {
+			f.InitSystem = initSystem.Type.String()
+		}
+		recordErr("init-system", err)
+	}
+
+	host, err := arch.HostArch()
+	if err == nil {
+		f.Arch = string(host.Userland)
+		f.KernelArch = string(host.Kernel)
+	}
+	recordErr("arch", err)
+
+	container, err := os.DefaultDetector().Container()
+	if err == nil {
+		f.Container = container.Runtime.String()
+	}
+	recordErr("container", err)
+
+	virt, err := os.DefaultDetector().Virtualization()
+	if err == nil {
+		f.Virtualization = virt.Hypervisor.String()
+	}
+	recordErr("virtualization", err)
+
+	cloud, err := os.CloudProvider()
+	if err == nil {
+		f.CloudProvider = cloud.Cloud.String()
+	}
+	recordErr("cloud-provider", err)
+
+	return f
+}
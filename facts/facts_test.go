@@ -0,0 +1,138 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package facts_test
+
+import (
+	"encoding/json"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	jujuos "github.com/juju/os/v2"
+	"github.com/juju/os/v2/arch"
+	"github.com/juju/os/v2/facts"
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type factsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&factsSuite{})
+
+func (s *factsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS != "linux" {
+		c.Skip("host facts are read from the filesystem on Linux")
+	}
+}
+
+const jammyOSRelease = `NAME="Ubuntu"
+ID=ubuntu
+ID_LIKE=debian
+VERSION_ID="22.04"
+VERSION_CODENAME=jammy
+UBUNTU_CODENAME=jammy
+`
+
+func (s *factsSuite) TestHostFacts(c *gc.C) {
+	root := c.MkDir()
+	hosttest.WriteFile(c, root, "etc/os-release", jammyOSRelease)
+	hosttest.WriteFile(c, root, "proc/sys/kernel/osrelease", "5.15.0-91-generic\n")
+	hosttest.WriteFile(c, root, "run/systemd/container", "docker\n")
+	hosttest.WriteFile(c, root, "sys/class/dmi/id/sys_vendor", "QEMU\n")
+	hosttest.WriteFile(c, root, "run/cloud-init/instance-data.json", `{"v1": {"cloud_name": "openstack"}}`)
+	hosttest.WriteFile(c, root, "run/systemd/system/multi-user.target.wants/ssh.service", "")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, root)
+
+	host, err := arch.HostArch()
+	c.Assert(err, jc.ErrorIsNil)
+
+	f := facts.HostFacts()
+	c.Check(f, jc.DeepEquals, facts.Facts{
+		OSType:         "Ubuntu",
+		Distribution:   "ubuntu",
+		Series:         "jammy",
+		Version:        "22.04",
+		Codename:       "jammy",
+		ReleaseFile:    "/etc/os-release",
+		Arch:           string(host.Userland),
+		KernelArch:     string(host.Kernel),
+		Kernel:         "5.15.0-91-generic",
+		Container:      "docker",
+		Virtualization: "kvm",
+		CloudProvider:  "openstack",
+		InitSystem:     "systemd",
+	})
+}
+
+func (s *factsSuite) TestHostFactsErrors(c *gc.C) {
+	s.PatchEnvironment(jujuos.HostRootEnvVar, c.MkDir())
+
+	f := facts.HostFacts()
+	c.Check(f.OSType, gc.Equals, "Unknown")
+	c.Check(f.Version, gc.Equals, "")
+	c.Check(f.Kernel, gc.Equals, "")
+	c.Check(f.Container, gc.Equals, "none")
+	c.Check(f.Virtualization, gc.Equals, "none")
+	c.Check(f.CloudProvider, gc.Equals, "unknown")
+	c.Check(f.InitSystem, gc.Equals, "unknown")
+	c.Check(f.Errors, gc.HasLen, 4)
+	c.Check(f.Errors["os-type"], gc.Matches, "release file not found .*")
+	c.Check(f.Errors["series"], gc.Matches, "cannot determine host series: .*")
+	c.Check(f.Errors["version"], gc.Matches, "release file not found .*")
+	c.Check(f.Errors["kernel"], gc.Matches, ".*no such file or directory")
+}
+
+func (s *factsSuite) TestHostFactsProbeErrors(c *gc.C) {
+	root := c.MkDir()
+	hosttest.WriteFile(c, root, "etc/os-release", jammyOSRelease)
+	// Directories where files are expected cannot be read.
+	hosttest.WriteFile(c, root, "run/cloud-init/instance-data.json/x", "")
+	hosttest.WriteFile(c, root, "proc/1/comm/x", "")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, root)
+
+	f := facts.HostFacts()
+	c.Check(f.CloudProvider, gc.Equals, "")
+	c.Check(f.InitSystem, gc.Equals, "")
+	c.Check(f.Errors["cloud-provider"], gc.Matches, ".*is a directory")
+	c.Check(f.Errors["init-system"], gc.Matches, ".*is a directory")
+}
+
+func (s *factsSuite) TestMarshal(c *gc.C) {
+	f := facts.Facts{
+		OSType:    "Ubuntu",
+		Series:    "jammy",
+		Version:   "22.04",
+		Container: "none",
+		Errors:    map[string]string{"kernel": "boom"},
+	}
+
+	data, err := json.Marshal(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals,
+		`{"os-type":"Ubuntu","series":"jammy","version":"22.04","container":"none","errors":{"kernel":"boom"}}`)
+	var fromJSON facts.Facts
+	err = json.Unmarshal(data, &fromJSON)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fromJSON, jc.DeepEquals, f)
+
+	data, err = yaml.Marshal(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `
+os-type: Ubuntu
+series: jammy
+version: "22.04"
+container: none
+errors:
+  kernel: boom
+`[1:])
+	var fromYAML facts.Facts
+	err = yaml.Unmarshal(data, &fromYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fromYAML, jc.DeepEquals, f)
+}
@@ -4,16 +4,17 @@ go 1.17
 
 require (
 	github.com/golang/mock v1.5.0
+	github.com/juju/clock v0.0.0-20220202072423-1b0f830854c4
 	github.com/juju/collections v0.0.0-20220203020748-febd7cad8a7a
 	github.com/juju/errors v0.0.0-20220203013757-bd733f3c86b9
 	github.com/juju/loggo v0.0.0-20210728185423-eebad3a902c4
 	github.com/juju/testing v0.0.0-20220203020004-a0ff61f03494
 	golang.org/x/sys v0.5.0
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 require (
-	github.com/juju/clock v0.0.0-20220202072423-1b0f830854c4 // indirect
 	github.com/juju/mgo/v2 v2.0.0-20210302023703-70d5d206e208 // indirect
 	github.com/juju/retry v0.0.0-20180821225755-9058e192b216 // indirect
 	github.com/juju/utils/v3 v3.0.0-20220202114721-338bb0530e89 // indirect
@@ -22,5 +23,4 @@ require (
 	github.com/kr/text v0.2.0 // indirect
 	golang.org/x/crypto v0.0.0-20211215153901-e495a2d5b3d3 // indirect
 	golang.org/x/net v0.7.0 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
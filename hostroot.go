@@ -0,0 +1,84 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	goos "os"
+	"path/filepath"
+	"sync"
+)
+
+// HostRootEnvVar is the environment variable that sets the directory the
+// host's root filesystem is mounted at, for processes running in a
+// container with the host filesystem mounted, e.g. at /host.
+const HostRootEnvVar = "JUJU_HOST_ROOT"
+
+var (
+	hostRootMutex sync.RWMutex
+	hostRoot      string
+)
+
+// HostRoot returns the directory the host's root filesystem is mounted at.
+// This is the root set by SetHostRoot, or if none has been set, the value
+// of the JUJU_HOST_ROOT environment variable, or "/" otherwise.
+func HostRoot() string {
+	hostRootMutex.RLock()
+	root := hostRoot
+	hostRootMutex.RUnlock()
+	if root != "" {
+		return root
+	}
+	if root := goos.Getenv(HostRootEnvVar); root != "" {
+		return filepath.Clean(root)
+	}
+	return "/"
+}
+
+// SetHostRoot sets the directory the host's root filesystem is mounted at.
+// Every file read to describe the host is read relative to it. Setting an
+// empty root restores the default.
+func SetHostRoot(root string) {
+	if root != "" {
+		root = filepath.Clean(root)
+	}
+	hostRootMutex.Lock()
+	hostRoot = root
+	hostRootMutex.Unlock()
+}
+
+// HostFS returns the host's root filesystem, rooted at HostRoot. The
+// filesystem can also read symbolic links; see ReadLink.
+func HostFS() fs.FS {
+	root := HostRoot()
+	return dirFS{FS: goos.DirFS(root), dir: root}
+}
+
+// ReadLinkFS is implemented by filesystems that can read symbolic links.
+type ReadLinkFS interface {
+	fs.FS
+
+	// ReadLink returns the destination of the named symbolic link.
+	ReadLink(name string) (string, error)
+}
+
+// dirFS is a directory tree of the host that can read symbolic links.
+type dirFS struct {
+	fs.FS
+	dir string
+}
+
+// ReadLink is part of the ReadLinkFS interface.
+func (f dirFS) ReadLink(name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return goos.Readlink(filepath.Join(f.dir, filepath.FromSlash(name)))
+}
+
+// HostPath returns the location of the host's absolute path p, relative to
+// HostRoot.
+func HostPath(p string) string {
+	return filepath.Join(HostRoot(), p)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	goos "os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type hostRootSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&hostRootSuite{})
+
+func (s *hostRootSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchEnvironment(HostRootEnvVar, "")
+	s.AddCleanup(func(*gc.C) { SetHostRoot("") })
+}
+
+func (s *hostRootSuite) TestHostRootDefault(c *gc.C) {
+	c.Assert(HostRoot(), gc.Equals, "/")
+}
+
+func (s *hostRootSuite) TestHostRootEnvironment(c *gc.C) {
+	s.PatchEnvironment(HostRootEnvVar, "/host/")
+	c.Assert(HostRoot(), gc.Equals, filepath.Clean("/host"))
+}
+
+func (s *hostRootSuite) TestSetHostRoot(c *gc.C) {
+	s.PatchEnvironment(HostRootEnvVar, "/host")
+	SetHostRoot("/mnt/root/")
+	c.Assert(HostRoot(), gc.Equals, filepath.Clean("/mnt/root"))
+
+	SetHostRoot("")
+	c.Assert(HostRoot(), gc.Equals, filepath.Clean("/host"))
+}
+
+func (s *hostRootSuite) TestHostPath(c *gc.C) {
+	c.Check(HostPath("/etc/os-release"), gc.Equals, filepath.Clean("/etc/os-release"))
+
+	SetHostRoot("/host")
+	c.Check(HostPath("/etc/os-release"), gc.Equals, filepath.Join("/host", "etc", "os-release"))
+}
+
+func (s *hostRootSuite) TestHostFS(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", jammyOSRelease)
+
+	SetHostRoot(d)
+	contents, err := fs.ReadFile(HostFS(), "etc/os-release")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(contents), gc.Equals, jammyOSRelease)
+}
+
+func (s *hostRootSuite) TestHostDistributionFollowsHostRoot(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("host root only applies to Linux")
+	}
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=fedora\nVERSION_ID=39\n")
+
+	s.PatchEnvironment(HostRootEnvVar, d)
+	distro, err := HostDistribution()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(distro.OSType, gc.Equals, Fedora)
+	c.Check(distro.Source, gc.Equals, "/etc/os-release")
+
+	SetHostRoot(c.MkDir())
+	_, err = HostDistribution()
+	c.Assert(err, jc.Satisfies, IsReleaseNotFoundError)
+}
+
+func (s *hostRootSuite) TestHostFSReadLink(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("symbolic links need privileges on Windows")
+	}
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "lib/systemd/systemd", "")
+	err := goos.MkdirAll(filepath.Join(d, "sbin"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = goos.Symlink("/lib/systemd/systemd", filepath.Join(d, "sbin", "init"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	SetHostRoot(d)
+	fsys, ok := HostFS().(ReadLinkFS)
+	c.Assert(ok, jc.IsTrue)
+	dest, err := fsys.ReadLink("sbin/init")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dest, gc.Equals, "/lib/systemd/systemd")
+
+	_, err = fsys.ReadLink("/sbin/init")
+	c.Check(err, gc.ErrorMatches, "readlink /sbin/init: invalid argument")
+}
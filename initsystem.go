@@ -0,0 +1,161 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// InitSystemType identifies the init system or service manager that
+// manages services.
+type InitSystemType int
+
+const (
+	UnknownInitSystem InitSystemType = iota
+	SystemdInit
+	UpstartInit
+	SysVInit
+	OpenRCInit
+	// LaunchdInit is the service manager of macOS.
+	LaunchdInit
+	// WindowsServiceInit is the Windows service control manager.
+	WindowsServiceInit
+)
+
+var initSystemNames = map[InitSystemType]string{
+	UnknownInitSystem:  "unknown",
+	SystemdInit:        "systemd",
+	UpstartInit:        "upstart",
+	SysVInit:           "sysvinit",
+	OpenRCInit:         "openrc",
+	LaunchdInit:        "launchd",
+	WindowsServiceInit: "windows",
+}
+
+func (t InitSystemType) String() string {
+	if name, ok := initSystemNames[t]; ok {
+		return name
+	}
+	return initSystemNames[UnknownInitSystem]
+}
+
+// InitSystem describes the init system of a machine.
+type InitSystem struct {
+	Type InitSystemType
+
+	// Version is the version of systemd, such as 252, if known.
+	Version int
+}
+
+// HostInitSystem returns the init system of the host, read relative to
+// HostRoot.
+func HostInitSystem() (InitSystem, error) {
+	return DetectInitSystem(HostFS())
+}
+
+// DetectInitSystem returns the init system of the root filesystem fsys,
+// which is expected to include /proc and /run. The /run/systemd/system
+// directory identifies a machine booted with systemd; otherwise the name
+// and executable of process 1 are inspected. The executable is only
+// consulted if fsys implements ReadLinkFS.
+func DetectInitSystem(fsys fs.FS) (InitSystem, error) {
+	result := InitSystem{Type: UnknownInitSystem}
+
+	info, err := fs.Stat(fsys, fsPath("/run/systemd/system"))
+	if err == nil && info.IsDir() {
+		result.Type = SystemdInit
+	} else {
+		comm, _, err := readProbeFile(fsys, "/proc/1/comm")
+		if err != nil {
+			return InitSystem{}, errors.Trace(err)
+		}
+		var exe string
+		if lfs, ok := fsys.(ReadLinkFS); ok {
+			// The link cannot be read without privileges.
+			exe, _ = lfs.ReadLink(fsPath("/proc/1/exe"))
+		}
+		if result.Type, err = initSystemFromProcess(fsys, strings.TrimSpace(string(comm)), path.Base(exe)); err != nil {
+			return InitSystem{}, errors.Trace(err)
+		}
+	}
+
+	if result.Type == SystemdInit {
+		if result.Version, err = systemdVersion(fsys); err != nil {
+			return InitSystem{}, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// initSystemFromProcess returns the init system given the name and the
+// executable of process 1, either of which may be empty.
+func initSystemFromProcess(fsys fs.FS, comm, exe string) (InitSystemType, error) {
+	for _, name := range []string{exe, comm} {
+		switch {
+		case name == "systemd":
+			return SystemdInit, nil
+		case name == "upstart":
+			return UpstartInit, nil
+		case name == "openrc-init":
+			return OpenRCInit, nil
+		}
+	}
+	if comm != "init" && exe != "init" {
+		return UnknownInitSystem, nil
+	}
+
+	// Upstart, OpenRC on sysvinit and sysvinit itself all run as init,
+	// so they are told apart by the files they install.
+	for _, probe := range []struct {
+		path     string
+		initType InitSystemType
+	}{
+		{path: "/sbin/initctl", initType: UpstartInit},
+		{path: "/run/openrc", initType: OpenRCInit},
+		{path: "/sbin/openrc", initType: OpenRCInit},
+	} {
+		if exists, err := probeExists(fsys, probe.path); err != nil {
+			return UnknownInitSystem, errors.Trace(err)
+		} else if exists {
+			return probe.initType, nil
+		}
+	}
+	return SysVInit, nil
+}
+
+// systemdSharedLibraryGlobs match the private library shipped with
+// systemd, which is named after its version.
+var systemdSharedLibraryGlobs = []string{
+	"usr/lib/systemd/libsystemd-shared-*.so",
+	"lib/systemd/libsystemd-shared-*.so",
+	"usr/lib/*/systemd/libsystemd-shared-*.so",
+	"usr/lib64/systemd/libsystemd-shared-*.so",
+}
+
+// systemdVersion returns the version of the systemd installed in fsys, or
+// 0 if it cannot be determined.
+func systemdVersion(fsys fs.FS) (int, error) {
+	version := 0
+	for _, pattern := range systemdSharedLibraryGlobs {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		for _, match := range matches {
+			v := strings.TrimPrefix(path.Base(match), "libsystemd-shared-")
+			if i := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' }); i != -1 {
+				v = v[:i]
+			}
+			if n, err := strconv.Atoi(v); err == nil && n > version {
+				version = n
+			}
+		}
+	}
+	return version, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type initSystemSuite struct {
+}
+
+var _ = gc.Suite(&initSystemSuite{})
+
+// linkFS is a fstest.MapFS with symbolic links.
+type linkFS struct {
+	fstest.MapFS
+	links map[string]string
+}
+
+func (f linkFS) ReadLink(name string) (string, error) {
+	if dest, ok := f.links[name]; ok {
+		return dest, nil
+	}
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrNotExist}
+}
+
+var detectInitSystemTests = []struct {
+	message  string
+	fsys     fs.FS
+	initType InitSystemType
+	version  int
+}{{
+	message: "systemd booted",
+	fsys: fstest.MapFS{
+		"run/systemd/system": {Mode: fs.ModeDir},
+		"proc/1/comm":        {Data: []byte("systemd\n")},
+		"usr/lib/x86_64-linux-gnu/systemd/libsystemd-shared-252.so": {},
+	},
+	initType: SystemdInit,
+	version:  252,
+}, {
+	message: "systemd with versioned library",
+	fsys: fstest.MapFS{
+		"run/systemd/system": {Mode: fs.ModeDir},
+		"usr/lib/systemd/libsystemd-shared-255.4-1ubuntu8.so": {},
+	},
+	initType: SystemdInit,
+	version:  255,
+}, {
+	message: "systemd without library",
+	fsys: fstest.MapFS{
+		"proc/1/comm": {Data: []byte("systemd\n")},
+	},
+	initType: SystemdInit,
+}, {
+	message: "systemd running as init",
+	fsys: linkFS{
+		MapFS: fstest.MapFS{
+			"proc/1/comm":                          {Data: []byte("init\n")},
+			"lib/systemd/libsystemd-shared-237.so": {},
+		},
+		links: map[string]string{"proc/1/exe": "/lib/systemd/systemd"},
+	},
+	initType: SystemdInit,
+	version:  237,
+}, {
+	message: "upstart",
+	fsys: fstest.MapFS{
+		"proc/1/comm":  {Data: []byte("init\n")},
+		"sbin/initctl": {},
+		"sbin/init":    {},
+	},
+	initType: UpstartInit,
+}, {
+	message: "openrc",
+	fsys: fstest.MapFS{
+		"proc/1/comm": {Data: []byte("init\n")},
+		"run/openrc":  {Mode: fs.ModeDir},
+	},
+	initType: OpenRCInit,
+}, {
+	message: "openrc-init",
+	fsys: fstest.MapFS{
+		"proc/1/comm": {Data: []byte("openrc-init\n")},
+	},
+	initType: OpenRCInit,
+}, {
+	message: "sysvinit",
+	fsys: fstest.MapFS{
+		"proc/1/comm": {Data: []byte("init\n")},
+	},
+	initType: SysVInit,
+}, {
+	message: "container entrypoint",
+	fsys: fstest.MapFS{
+		"proc/1/comm": {Data: []byte("bash\n")},
+	},
+	initType: UnknownInitSystem,
+}, {
+	message:  "nothing readable",
+	fsys:     fstest.MapFS{},
+	initType: UnknownInitSystem,
+}}
+
+func (s *initSystemSuite) TestDetectInitSystem(c *gc.C) {
+	for i, t := range detectInitSystemTests {
+		c.Logf("test %d: %s", i, t.message)
+		result, err := DetectInitSystem(t.fsys)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, InitSystem{Type: t.initType, Version: t.version})
+	}
+}
+
+func (s *initSystemSuite) TestInitSystemTypeString(c *gc.C) {
+	c.Check(SystemdInit.String(), gc.Equals, "systemd")
+	c.Check(SysVInit.String(), gc.Equals, "sysvinit")
+	c.Check(InitSystemType(99).String(), gc.Equals, "unknown")
+}
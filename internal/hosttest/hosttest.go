@@ -0,0 +1,25 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package hosttest provides helpers for tests that describe a host with
+// the files of a fake root filesystem.
+package hosttest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+// WriteFile writes contents to the file name, a slash separated path below
+// the root directory of a fake host, creating the directories it is in.
+func WriteFile(c *gc.C, root, name, contents string) {
+	path := filepath.Join(root, filepath.FromSlash(name))
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(contents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
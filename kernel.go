@@ -0,0 +1,140 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// KernelVersion is a Linux kernel release, as reported by uname -r.
+type KernelVersion struct {
+	Major int
+	Minor int
+	Patch int
+
+	// ABI is the ABI number of a distribution kernel, such as 91 in
+	// "5.15.0-91-generic" or 513 in "4.18.0-513.el8.x86_64".
+	ABI int
+
+	// Flavour is the flavour of a Debian or Ubuntu kernel, such as
+	// "generic" or "aws".
+	Flavour string
+
+	// Suffix is the remainder of the release that identifies the
+	// distribution build, such as "el8.x86_64".
+	Suffix string
+
+	// Raw is the release the version was parsed from.
+	Raw string
+}
+
+var (
+	// kernelVersionRE matches the dotted version, any further version
+	// components and local version, and the release that follows.
+	kernelVersionRE = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.\d+)*[^-]*(?:-(.*))?$`)
+
+	// debianKernelReleaseRE matches releases such as "91-generic".
+	debianKernelReleaseRE = regexp.MustCompile(`^(\d+)(?:-(.+))?$`)
+
+	// redHatKernelReleaseRE matches releases such as "513.el8.x86_64".
+	redHatKernelReleaseRE = regexp.MustCompile(`^(\d+)\.(.+)$`)
+)
+
+// ParseKernelVersion parses a Linux kernel release such as
+// "5.15.0-91-generic", "6.8.0-1008-aws" or "4.18.0-513.el8.x86_64".
+func ParseKernelVersion(release string) (KernelVersion, error) {
+	release = strings.TrimSpace(release)
+	m := kernelVersionRE.FindStringSubmatch(release)
+	if m == nil {
+		return KernelVersion{}, errors.NotValidf("kernel version %q", release)
+	}
+	v := KernelVersion{Raw: release}
+	for i, field := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return KernelVersion{}, errors.NotValidf("kernel version %q", release)
+		}
+		*field = n
+	}
+
+	rest := m[4]
+	if rm := debianKernelReleaseRE.FindStringSubmatch(rest); rm != nil {
+		v.ABI, _ = strconv.Atoi(rm[1])
+		v.Flavour = rm[2]
+	} else if rm := redHatKernelReleaseRE.FindStringSubmatch(rest); rm != nil {
+		v.ABI, _ = strconv.Atoi(rm[1])
+		v.Suffix = rm[2]
+	} else {
+		v.Suffix = rest
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as, or newer
+// than other. The major, minor and patch versions are compared, followed
+// by the ABI number.
+func (v KernelVersion) Compare(other KernelVersion) int {
+	for _, pair := range [][2]int{
+		{v.Major, other.Major},
+		{v.Minor, other.Minor},
+		{v.Patch, other.Patch},
+		{v.ABI, other.ABI},
+	} {
+		switch {
+		case pair[0] < pair[1]:
+			return -1
+		case pair[0] > pair[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Less reports whether v is older than other.
+func (v KernelVersion) Less(other KernelVersion) bool {
+	return v.Compare(other) < 0
+}
+
+func (v KernelVersion) String() string {
+	if v.Raw != "" {
+		return v.Raw
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	switch {
+	case v.Flavour != "":
+		s += fmt.Sprintf("-%d-%s", v.ABI, v.Flavour)
+	case v.Suffix != "" && v.ABI != 0:
+		s += fmt.Sprintf("-%d.%s", v.ABI, v.Suffix)
+	case v.Suffix != "":
+		s += "-" + v.Suffix
+	case v.ABI != 0:
+		s += fmt.Sprintf("-%d", v.ABI)
+	}
+	return s
+}
+
+// HostKernelVersion returns the version of the running Linux kernel, read
+// relative to HostRoot.
+func HostKernelVersion() (KernelVersion, error) {
+	return ReadKernelVersion(HostFS())
+}
+
+// ReadKernelVersion returns the version of the running Linux kernel from
+// /proc/sys/kernel/osrelease in the root filesystem fsys.
+func ReadKernelVersion(fsys fs.FS) (KernelVersion, error) {
+	contents, err := fs.ReadFile(fsys, fsPath("/proc/sys/kernel/osrelease"))
+	if err != nil {
+		return KernelVersion{}, errors.Trace(err)
+	}
+	return ParseKernelVersion(string(contents))
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type kernelSuite struct {
+}
+
+var _ = gc.Suite(&kernelSuite{})
+
+var parseKernelVersionTests = []struct {
+	release string
+	version KernelVersion
+}{{
+	release: "5.15.0-91-generic",
+	version: KernelVersion{Major: 5, Minor: 15, Patch: 0, ABI: 91, Flavour: "generic"},
+}, {
+	release: "6.8.0-1008-aws",
+	version: KernelVersion{Major: 6, Minor: 8, Patch: 0, ABI: 1008, Flavour: "aws"},
+}, {
+	release: "4.18.0-513.el8.x86_64",
+	version: KernelVersion{Major: 4, Minor: 18, Patch: 0, ABI: 513, Suffix: "el8.x86_64"},
+}, {
+	release: "5.14.0-362.8.1.el9_3.x86_64",
+	version: KernelVersion{Major: 5, Minor: 14, Patch: 0, ABI: 362, Suffix: "8.1.el9_3.x86_64"},
+}, {
+	release: "6.1.0-13-amd64",
+	version: KernelVersion{Major: 6, Minor: 1, Patch: 0, ABI: 13, Flavour: "amd64"},
+}, {
+	release: "6.6.7-arch1-1",
+	version: KernelVersion{Major: 6, Minor: 6, Patch: 7, Suffix: "arch1-1"},
+}, {
+	release: "5.15.133.1-microsoft-standard-WSL2",
+	version: KernelVersion{Major: 5, Minor: 15, Patch: 133, Suffix: "microsoft-standard-WSL2"},
+}, {
+	release: "6.5.0",
+	version: KernelVersion{Major: 6, Minor: 5, Patch: 0},
+}, {
+	release: "4.19.0+",
+	version: KernelVersion{Major: 4, Minor: 19, Patch: 0},
+}, {
+	release: "6.9\n",
+	version: KernelVersion{Major: 6, Minor: 9},
+}}
+
+func (s *kernelSuite) TestParseKernelVersion(c *gc.C) {
+	for i, t := range parseKernelVersionTests {
+		c.Logf("test %d: %s", i, t.release)
+		v, err := ParseKernelVersion(t.release)
+		c.Assert(err, jc.ErrorIsNil)
+		t.version.Raw = v.Raw
+		c.Check(v, jc.DeepEquals, t.version)
+		c.Check(v.String(), gc.Equals, v.Raw)
+	}
+}
+
+func (s *kernelSuite) TestParseKernelVersionInvalid(c *gc.C) {
+	for _, release := range []string{"", "generic", "v5.15.0"} {
+		_, err := ParseKernelVersion(release)
+		c.Check(err, gc.ErrorMatches, `kernel version ".*" not valid`)
+	}
+}
+
+func (s *kernelSuite) TestKernelVersionString(c *gc.C) {
+	c.Check(KernelVersion{Major: 5, Minor: 15, ABI: 91, Flavour: "generic"}.String(), gc.Equals, "5.15.0-91-generic")
+	c.Check(KernelVersion{Major: 4, Minor: 18, ABI: 513, Suffix: "el8.x86_64"}.String(), gc.Equals, "4.18.0-513.el8.x86_64")
+	c.Check(KernelVersion{Major: 6, Minor: 6, Patch: 7, Suffix: "arch1-1"}.String(), gc.Equals, "6.6.7-arch1-1")
+	c.Check(KernelVersion{Major: 6, Minor: 5}.String(), gc.Equals, "6.5.0")
+}
+
+func (s *kernelSuite) TestKernelVersionCompare(c *gc.C) {
+	ordered := []string{
+		"4.18.0-513.el8.x86_64",
+		"5.4.0-150-generic",
+		"5.15.0-91-generic",
+		"5.15.0-101-generic",
+		"5.15.1",
+		"6.8.0-1008-aws",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := ParseKernelVersion(ordered[i])
+			c.Assert(err, jc.ErrorIsNil)
+			b, err := ParseKernelVersion(ordered[j])
+			c.Assert(err, jc.ErrorIsNil)
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			c.Check(a.Compare(b), gc.Equals, expected, gc.Commentf("%s vs %s", a, b))
+			c.Check(a.Less(b), gc.Equals, i < j)
+		}
+	}
+}
+
+func (s *kernelSuite) TestReadKernelVersion(c *gc.C) {
+	v, err := ReadKernelVersion(fstest.MapFS{
+		"proc/sys/kernel/osrelease": {Data: []byte("5.15.0-91-generic\n")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.Major, gc.Equals, 5)
+	c.Check(v.Flavour, gc.Equals, "generic")
+
+	_, err = ReadKernelVersion(fstest.MapFS{})
+	c.Check(err, gc.ErrorMatches, "open proc/sys/kernel/osrelease: .*")
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	goos "os"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// KernelConfig holds the options a Linux kernel was built with.
+type KernelConfig struct {
+	// Options maps each option name, such as "CONFIG_ZFS", onto its value.
+	// Options that are explicitly not set have the value "n".
+	Options map[string]string
+
+	// Source is the path of the file the config was read from, if any.
+	Source string
+}
+
+// kernelConfigName returns the name of an option with the CONFIG_ prefix,
+// which may be omitted by callers.
+func kernelConfigName(name string) string {
+	if strings.HasPrefix(name, "CONFIG_") {
+		return name
+	}
+	return "CONFIG_" + name
+}
+
+// Value returns the value of the named option, or an empty string if it
+// does not appear in the config. The CONFIG_ prefix may be omitted.
+func (c *KernelConfig) Value(name string) string {
+	return c.Options[kernelConfigName(name)]
+}
+
+// Enabled reports whether the named option is built in or built as a
+// module.
+func (c *KernelConfig) Enabled(name string) bool {
+	v := c.Value(name)
+	return v == "y" || v == "m"
+}
+
+// Builtin reports whether the named option is built into the kernel.
+func (c *KernelConfig) Builtin(name string) bool {
+	return c.Value(name) == "y"
+}
+
+// Module reports whether the named option is built as a module.
+func (c *KernelConfig) Module(name string) bool {
+	return c.Value(name) == "m"
+}
+
+// ParseKernelConfig parses a kernel build config, in the format of
+// /boot/config-*.
+func ParseKernelConfig(r io.Reader) (*KernelConfig, error) {
+	config := &KernelConfig{Options: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "# CONFIG_") && strings.HasSuffix(line, " is not set") {
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "# "), " is not set")
+			config.Options[name] = "n"
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		config.Options[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return config, nil
+}
+
+// HostKernelConfig returns the config of the running kernel, read relative
+// to HostRoot.
+func HostKernelConfig() (*KernelConfig, error) {
+	fsys := HostFS()
+	version, err := ReadKernelVersion(fsys)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ReadKernelConfig(fsys, version.Raw)
+}
+
+// ReadKernelConfig returns the config of the kernel with the given release
+// from the root filesystem fsys. The config of the running kernel in
+// /proc/config.gz is preferred, followed by /boot/config-<release>. A
+// file that cannot be read for lack of permission is passed over. If
+// neither exists an error satisfying errors.IsNotFound is returned.
+func ReadKernelConfig(fsys fs.FS, release string) (*KernelConfig, error) {
+	paths := []string{"/proc/config.gz", "/boot/config-" + release}
+	var denied error
+	for _, p := range paths {
+		contents, err := fs.ReadFile(fsys, fsPath(p))
+		if goos.IsNotExist(err) {
+			continue
+		} else if goos.IsPermission(err) {
+			if denied == nil {
+				denied = err
+			}
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var r io.Reader = bytes.NewReader(contents)
+		if path.Ext(p) == ".gz" {
+			if r, err = gzip.NewReader(r); err != nil {
+				return nil, errors.Annotatef(err, "reading %s", p)
+			}
+		}
+		config, err := ParseKernelConfig(r)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", p)
+		}
+		config.Source = p
+		return config, nil
+	}
+	if denied != nil {
+		return nil, errors.Trace(denied)
+	}
+	return nil, errors.NotFoundf("kernel config (tried %s)", strings.Join(paths, ", "))
+}
+
+// KernelModules describes the modules of a Linux kernel.
+type KernelModules struct {
+	loaded    map[string]bool
+	available map[string]bool
+}
+
+// kernelModuleName normalises a module name, as the kernel treats dashes
+// and underscores in module names as equivalent.
+func kernelModuleName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// Loaded reports whether the named module is loaded.
+func (m *KernelModules) Loaded(name string) bool {
+	return m.loaded[kernelModuleName(name)]
+}
+
+// Available reports whether the named module is loaded, can be loaded or
+// is built into the kernel.
+func (m *KernelModules) Available(name string) bool {
+	name = kernelModuleName(name)
+	return m.loaded[name] || m.available[name]
+}
+
+// HostKernelModules returns the modules of the running kernel, read
+// relative to HostRoot.
+func HostKernelModules() (*KernelModules, error) {
+	fsys := HostFS()
+	version, err := ReadKernelVersion(fsys)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ReadKernelModules(fsys, version.Raw)
+}
+
+// ReadKernelModules returns the modules of the kernel with the given
+// release from the root filesystem fsys. Loaded modules are read from
+// /proc/modules, and available modules from modules.dep and modules.builtin
+// in /lib/modules/<release>. Files that do not exist are taken to list no
+// modules.
+func ReadKernelModules(fsys fs.FS, release string) (*KernelModules, error) {
+	modules := &KernelModules{
+		loaded:    make(map[string]bool),
+		available: make(map[string]bool),
+	}
+
+	// Each line of /proc/modules starts with the module name.
+	err := readKernelModuleLines(fsys, "/proc/modules", func(line string) {
+		modules.loaded[kernelModuleName(strings.Fields(line)[0])] = true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// modules.dep lists each module file followed by a colon and its
+	// dependencies, and modules.builtin lists the built in module files.
+	dir := path.Join("/lib/modules", release)
+	for _, name := range []string{"modules.dep", "modules.builtin"} {
+		err := readKernelModuleLines(fsys, path.Join(dir, name), func(line string) {
+			file := path.Base(strings.SplitN(line, ":", 2)[0])
+			modules.available[kernelModuleName(strings.SplitN(file, ".ko", 2)[0])] = true
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return modules, nil
+}
+
+// readKernelModuleLines calls f with each non-empty line of the file at
+// the absolute path name in fsys, if it exists.
+func readKernelModuleLines(fsys fs.FS, name string, f func(string)) error {
+	contents, ok, err := readProbeFile(fsys, name)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			f(line)
+		}
+	}
+	return nil
+}
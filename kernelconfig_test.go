@@ -0,0 +1,159 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	goos "os"
+	"testing/fstest"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type kernelConfigSuite struct {
+}
+
+var _ = gc.Suite(&kernelConfigSuite{})
+
+const kernelConfigContents = `#
+# Automatically generated file; DO NOT EDIT.
+# Linux/x86 5.15.0-91-generic Kernel Configuration
+#
+CONFIG_CC_VERSION_TEXT="gcc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0"
+CONFIG_USER_NS=y
+CONFIG_ZFS=m
+# CONFIG_NFSD_V2 is not set
+CONFIG_LOG_BUF_SHIFT=18
+`
+
+func gzipped(c *gc.C, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigBoot(c *gc.C) {
+	config, err := ReadKernelConfig(fstest.MapFS{
+		"boot/config-5.15.0-91-generic": {Data: []byte(kernelConfigContents)},
+		"boot/config-5.15.0-90-generic": {Data: []byte("CONFIG_ZFS=y\n")},
+	}, "5.15.0-91-generic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Source, gc.Equals, "/boot/config-5.15.0-91-generic")
+
+	c.Check(config.Enabled("CONFIG_USER_NS"), jc.IsTrue)
+	c.Check(config.Builtin("USER_NS"), jc.IsTrue)
+	c.Check(config.Enabled("ZFS"), jc.IsTrue)
+	c.Check(config.Module("ZFS"), jc.IsTrue)
+	c.Check(config.Builtin("ZFS"), jc.IsFalse)
+	c.Check(config.Enabled("NFSD_V2"), jc.IsFalse)
+	c.Check(config.Value("NFSD_V2"), gc.Equals, "n")
+	c.Check(config.Enabled("MISSING"), jc.IsFalse)
+	c.Check(config.Value("MISSING"), gc.Equals, "")
+	c.Check(config.Value("LOG_BUF_SHIFT"), gc.Equals, "18")
+	c.Check(config.Value("CC_VERSION_TEXT"), gc.Equals, "gcc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0")
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigProc(c *gc.C) {
+	config, err := ReadKernelConfig(fstest.MapFS{
+		"proc/config.gz":                {Data: gzipped(c, kernelConfigContents)},
+		"boot/config-5.15.0-91-generic": {Data: []byte("CONFIG_ZFS=y\n")},
+	}, "5.15.0-91-generic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Source, gc.Equals, "/proc/config.gz")
+	c.Check(config.Module("ZFS"), jc.IsTrue)
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigNotFound(c *gc.C) {
+	_, err := ReadKernelConfig(fstest.MapFS{}, "5.15.0-91-generic")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `kernel config \(tried /proc/config.gz, /boot/config-5.15.0-91-generic\) not found`)
+}
+
+// deniedFS is a filesystem whose named files cannot be opened for lack
+// of permission.
+type deniedFS struct {
+	fs.FS
+	denied map[string]bool
+}
+
+func (d deniedFS) Open(name string) (fs.File, error) {
+	if d.denied[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return d.FS.Open(name)
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigProcDenied(c *gc.C) {
+	config, err := ReadKernelConfig(deniedFS{
+		FS: fstest.MapFS{
+			"proc/config.gz":                {Data: gzipped(c, "CONFIG_ZFS=y\n")},
+			"boot/config-5.15.0-91-generic": {Data: []byte(kernelConfigContents)},
+		},
+		denied: map[string]bool{"proc/config.gz": true},
+	}, "5.15.0-91-generic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Source, gc.Equals, "/boot/config-5.15.0-91-generic")
+	c.Check(config.Module("ZFS"), jc.IsTrue)
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigDenied(c *gc.C) {
+	_, err := ReadKernelConfig(deniedFS{
+		FS: fstest.MapFS{
+			"proc/config.gz": {Data: gzipped(c, kernelConfigContents)},
+		},
+		denied: map[string]bool{"proc/config.gz": true},
+	}, "5.15.0-91-generic")
+	c.Assert(errors.Cause(err), jc.Satisfies, goos.IsPermission)
+}
+
+func (s *kernelConfigSuite) TestReadKernelConfigBadGzip(c *gc.C) {
+	_, err := ReadKernelConfig(fstest.MapFS{
+		"proc/config.gz": {Data: []byte(kernelConfigContents)},
+	}, "5.15.0-91-generic")
+	c.Assert(err, gc.ErrorMatches, "reading /proc/config.gz: .*")
+}
+
+func (s *kernelConfigSuite) TestReadKernelModules(c *gc.C) {
+	modules, err := ReadKernelModules(fstest.MapFS{
+		"proc/modules": {Data: []byte(`zfs 3997696 6 - Live 0x0000000000000000 (PO)
+nf_conntrack 172032 1 xt_conntrack, Live 0x0000000000000000
+`)},
+		"lib/modules/5.15.0-91-generic/modules.dep": {Data: []byte(`kernel/fs/zfs/zfs.ko.zst: kernel/fs/zfs/spl.ko.zst
+kernel/fs/zfs/spl.ko.zst:
+kernel/net/8021q/8021q.ko:
+kernel/drivers/net/vxlan/vxlan.ko: kernel/net/ipv6/ip6_udp_tunnel.ko
+`)},
+		"lib/modules/5.15.0-91-generic/modules.builtin": {Data: []byte(`kernel/fs/ext4/ext4.ko
+kernel/net/bridge/br-netfilter.ko
+`)},
+	}, "5.15.0-91-generic")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(modules.Loaded("zfs"), jc.IsTrue)
+	c.Check(modules.Loaded("nf-conntrack"), jc.IsTrue)
+	c.Check(modules.Loaded("spl"), jc.IsFalse)
+
+	c.Check(modules.Available("zfs"), jc.IsTrue)
+	c.Check(modules.Available("spl"), jc.IsTrue)
+	c.Check(modules.Available("8021q"), jc.IsTrue)
+	c.Check(modules.Available("vxlan"), jc.IsTrue)
+	c.Check(modules.Available("ext4"), jc.IsTrue)
+	c.Check(modules.Available("br_netfilter"), jc.IsTrue)
+	c.Check(modules.Available("nf_conntrack"), jc.IsTrue)
+	c.Check(modules.Available("btrfs"), jc.IsFalse)
+}
+
+func (s *kernelConfigSuite) TestReadKernelModulesMissingFiles(c *gc.C) {
+	modules, err := ReadKernelModules(fstest.MapFS{}, "5.15.0-91-generic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modules.Loaded("zfs"), jc.IsFalse)
+	c.Check(modules.Available("zfs"), jc.IsFalse)
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	goos "os"
+	"sync"
+)
+
+// kubernetesServiceHostEnvVar is set by Kubernetes in every container of
+// a pod.
+const kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+// kubernetesServiceAccountDirs are the mount points of the Kubernetes
+// service account token in a pod.
+var kubernetesServiceAccountDirs = []string{
+	"/var/run/secrets/kubernetes.io/serviceaccount",
+	"/run/secrets/kubernetes.io/serviceaccount",
+}
+
+var (
+	kubernetesMutex     sync.RWMutex
+	kubernetesDetection bool
+)
+
+// SetKubernetesDetection sets whether HostOS reports Kubernetes, rather
+// than the distribution of the container image, when the current process
+// runs in a Kubernetes pod. It is disabled by default. The distribution of
+// the image is always available from HostDistribution.
+func SetKubernetesDetection(enabled bool) {
+	kubernetesMutex.Lock()
+	kubernetesDetection = enabled
+	kubernetesMutex.Unlock()
+}
+
+// KubernetesDetection reports whether Kubernetes detection has been
+// enabled with SetKubernetesDetection.
+func KubernetesDetection() bool {
+	kubernetesMutex.RLock()
+	defer kubernetesMutex.RUnlock()
+	return kubernetesDetection
+}
+
+// InKubernetesPod reports whether the host runs in a Kubernetes pod, as
+// indicated by KUBERNETES_SERVICE_HOST or a mounted service account token.
+// The environment describes the current process rather than the host, so
+// it is only consulted when no host root is set.
+func InKubernetesPod() bool {
+	if HostRoot() == "/" && goos.Getenv(kubernetesServiceHostEnvVar) != "" {
+		return true
+	}
+	return kubernetesTokenMounted(HostFS())
+}
+
+// kubernetesTokenMounted reports whether a Kubernetes service account
+// token is mounted in the root filesystem fsys.
+func kubernetesTokenMounted(fsys fs.FS) bool {
+	for _, dir := range kubernetesServiceAccountDirs {
+		if exists, _ := probeExists(fsys, dir+"/token"); exists {
+			return true
+		}
+	}
+	return false
+}
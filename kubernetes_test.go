@@ -0,0 +1,83 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"runtime"
+	"testing/fstest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type kubernetesSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&kubernetesSuite{})
+
+func (s *kubernetesSuite) SetUpTest(c *gc.C) {
+	s.CleanupSuite.SetUpTest(c)
+	s.PatchEnvironment(kubernetesServiceHostEnvVar, "")
+	s.PatchEnvironment(HostRootEnvVar, c.MkDir())
+	s.AddCleanup(func(*gc.C) { SetKubernetesDetection(false) })
+}
+
+func (s *kubernetesSuite) TestKubernetesTokenMounted(c *gc.C) {
+	c.Check(kubernetesTokenMounted(fstest.MapFS{}), jc.IsFalse)
+	c.Check(kubernetesTokenMounted(fstest.MapFS{
+		"var/run/secrets/kubernetes.io/serviceaccount/token": {Data: []byte("token")},
+	}), jc.IsTrue)
+	c.Check(kubernetesTokenMounted(fstest.MapFS{
+		"run/secrets/kubernetes.io/serviceaccount/token": {Data: []byte("token")},
+	}), jc.IsTrue)
+}
+
+func (s *kubernetesSuite) TestInKubernetesPodEnvironment(c *gc.C) {
+	s.PatchEnvironment(HostRootEnvVar, "")
+	s.PatchEnvironment(kubernetesServiceHostEnvVar, "10.152.183.1")
+	c.Check(InKubernetesPod(), jc.IsTrue)
+
+	// The environment does not describe a host mounted elsewhere.
+	s.PatchEnvironment(HostRootEnvVar, c.MkDir())
+	c.Check(InKubernetesPod(), jc.IsFalse)
+}
+
+func (s *kubernetesSuite) TestInKubernetesPodToken(c *gc.C) {
+	d := c.MkDir()
+	s.PatchEnvironment(HostRootEnvVar, d)
+	c.Check(InKubernetesPod(), jc.IsFalse)
+
+	hosttest.WriteFile(c, d, "run/secrets/kubernetes.io/serviceaccount/token", "token")
+	c.Check(InKubernetesPod(), jc.IsTrue)
+}
+
+func (s *kubernetesSuite) TestDetectHostOSKubernetes(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("Kubernetes pods are only detected on Linux")
+	}
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=ubuntu\nVERSION_ID=\"22.04\"\n")
+	hosttest.WriteFile(c, d, "var/run/secrets/kubernetes.io/serviceaccount/token", "token")
+	s.PatchEnvironment(HostRootEnvVar, d)
+
+	osType, err := DetectHostOS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Ubuntu)
+
+	SetKubernetesDetection(true)
+	c.Check(KubernetesDetection(), jc.IsTrue)
+	osType, err = DetectHostOS()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(osType, gc.Equals, Kubernetes)
+	c.Check(HostOS(), gc.Equals, Kubernetes)
+
+	// The distribution of the image is still available.
+	distro, err := HostDistribution()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(distro.OSType, gc.Equals, Ubuntu)
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PackageFormat identifies the format of the native packages of an OS.
+type PackageFormat int
+
+const (
+	UnknownPackageFormat PackageFormat = iota
+	DebPackageFormat
+	RPMPackageFormat
+	APKPackageFormat
+	PacmanPackageFormat
+	MSIPackageFormat
+	// PKGPackageFormat is the macOS installer package format.
+	PKGPackageFormat
+)
+
+var packageFormatNames = map[PackageFormat]string{
+	UnknownPackageFormat: "unknown",
+	DebPackageFormat:     "deb",
+	RPMPackageFormat:     "rpm",
+	APKPackageFormat:     "apk",
+	PacmanPackageFormat:  "pacman",
+	MSIPackageFormat:     "msi",
+	PKGPackageFormat:     "pkg",
+}
+
+func (f PackageFormat) String() string {
+	if name, ok := packageFormatNames[f]; ok {
+		return name
+	}
+	return packageFormatNames[UnknownPackageFormat]
+}
+
+// Metadata describes the packaging and system conventions of an OS.
+type Metadata struct {
+	Family Family
+
+	// PackageFormat is the format of the native packages.
+	PackageFormat PackageFormat
+
+	// PackageManagers are the commands that install native packages,
+	// preferred first.
+	PackageManagers []string
+
+	// ServiceManager is the default init system or service manager.
+	ServiceManager InitSystemType
+
+	// Shell is the default shell used to run scripts.
+	Shell string
+
+	// LineEnding is the line ending of text files.
+	LineEnding string
+}
+
+// familyMetadata holds the metadata shared by the members of each family.
+var familyMetadata = map[Family]Metadata{
+	DebianFamily: {
+		PackageFormat:   DebPackageFormat,
+		PackageManagers: []string{"apt"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+	},
+	RedHatFamily: {
+		PackageFormat:   RPMPackageFormat,
+		PackageManagers: []string{"dnf", "yum"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+	},
+	SUSEFamily: {
+		PackageFormat:   RPMPackageFormat,
+		PackageManagers: []string{"zypper"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+	},
+	ArchFamily: {
+		PackageFormat:   PacmanPackageFormat,
+		PackageManagers: []string{"pacman"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+	},
+	AlpineFamily: {
+		PackageFormat:   APKPackageFormat,
+		PackageManagers: []string{"apk"},
+		ServiceManager:  OpenRCInit,
+		Shell:           "/bin/sh",
+	},
+	DarwinFamily: {
+		PackageFormat:  PKGPackageFormat,
+		ServiceManager: LaunchdInit,
+		Shell:          "/bin/zsh",
+	},
+	WindowsFamily: {
+		PackageFormat:  MSIPackageFormat,
+		ServiceManager: WindowsServiceInit,
+		Shell:          "powershell.exe",
+		LineEnding:     "\r\n",
+	},
+}
+
+// Metadata returns the metadata of the latest release of the OS type.
+func (t OSType) Metadata() Metadata {
+	return t.MetadataForVersion("")
+}
+
+// MetadataForVersion returns the metadata of the given release of the OS
+// type, such as "7" for CentOS 7 or "14.04" for Ubuntu 14.04. If the
+// version is empty or not understood, the metadata of the latest release
+// is returned.
+func (t OSType) MetadataForVersion(version string) Metadata {
+	family := t.Family()
+	m := familyMetadata[family]
+	m.Family = family
+	m.PackageManagers = append([]string(nil), m.PackageManagers...)
+	if m.LineEnding == "" && family != UnknownFamily {
+		m.LineEnding = "\n"
+	}
+	if t.IsLinux() && family == UnknownFamily {
+		m.ServiceManager = SystemdInit
+		m.Shell = "/bin/sh"
+		m.LineEnding = "\n"
+	}
+
+	major, ok := parseMajorVersion(version)
+	if !ok {
+		return m
+	}
+	switch t {
+	case Ubuntu:
+		if major < 15 {
+			m.ServiceManager = UpstartInit
+		}
+	case Debian:
+		if major < 8 {
+			m.ServiceManager = SysVInit
+		}
+	case CentOS, RHEL:
+		if major < 7 {
+			m.ServiceManager = UpstartInit
+		}
+		if major < 8 {
+			m.PackageManagers = []string{"yum"}
+		}
+	case Fedora:
+		if major < 22 {
+			m.PackageManagers = []string{"yum"}
+		}
+	case AmazonLinux:
+		// Amazon Linux 1 is versioned by date, such as 2018.03, and
+		// Amazon Linux 2023 by year.
+		if major < 2022 {
+			m.PackageManagers = []string{"yum"}
+		}
+		if major > 2 && major < 2022 {
+			m.ServiceManager = UpstartInit
+		}
+	}
+	return m
+}
+
+// parseMajorVersion returns the leading number of a dotted version.
+func parseMajorVersion(version string) (int, bool) {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type metadataSuite struct {
+}
+
+var _ = gc.Suite(&metadataSuite{})
+
+func (s *metadataSuite) TestMetadata(c *gc.C) {
+	c.Check(Ubuntu.Metadata(), jc.DeepEquals, Metadata{
+		Family:          DebianFamily,
+		PackageFormat:   DebPackageFormat,
+		PackageManagers: []string{"apt"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+		LineEnding:      "\n",
+	})
+	c.Check(Rocky.Metadata(), jc.DeepEquals, Metadata{
+		Family:          RedHatFamily,
+		PackageFormat:   RPMPackageFormat,
+		PackageManagers: []string{"dnf", "yum"},
+		ServiceManager:  SystemdInit,
+		Shell:           "/bin/bash",
+		LineEnding:      "\n",
+	})
+	c.Check(Alpine.Metadata(), jc.DeepEquals, Metadata{
+		Family:          AlpineFamily,
+		PackageFormat:   APKPackageFormat,
+		PackageManagers: []string{"apk"},
+		ServiceManager:  OpenRCInit,
+		Shell:           "/bin/sh",
+		LineEnding:      "\n",
+	})
+	c.Check(Windows.Metadata(), jc.DeepEquals, Metadata{
+		Family:         WindowsFamily,
+		PackageFormat:  MSIPackageFormat,
+		ServiceManager: WindowsServiceInit,
+		Shell:          "powershell.exe",
+		LineEnding:     "\r\n",
+	})
+	c.Check(GenericLinux.Metadata(), jc.DeepEquals, Metadata{
+		ServiceManager: SystemdInit,
+		Shell:          "/bin/sh",
+		LineEnding:     "\n",
+	})
+	c.Check(Unknown.Metadata(), jc.DeepEquals, Metadata{})
+	c.Check(Kubernetes.Metadata(), jc.DeepEquals, Metadata{})
+}
+
+func (s *metadataSuite) TestMetadataForVersion(c *gc.C) {
+	for i, t := range []struct {
+		osType          OSType
+		version         string
+		packageManagers []string
+		serviceManager  InitSystemType
+	}{
+		{Ubuntu, "14.04", []string{"apt"}, UpstartInit},
+		{Ubuntu, "16.04", []string{"apt"}, SystemdInit},
+		{Debian, "7", []string{"apt"}, SysVInit},
+		{Debian, "12", []string{"apt"}, SystemdInit},
+		{CentOS, "6", []string{"yum"}, UpstartInit},
+		{CentOS, "7", []string{"yum"}, SystemdInit},
+		{CentOS, "8", []string{"dnf", "yum"}, SystemdInit},
+		{RHEL, "7.9", []string{"yum"}, SystemdInit},
+		{RHEL, "9.3", []string{"dnf", "yum"}, SystemdInit},
+		{Fedora, "21", []string{"yum"}, SystemdInit},
+		{Fedora, "39", []string{"dnf", "yum"}, SystemdInit},
+		{AmazonLinux, "2018.03", []string{"yum"}, UpstartInit},
+		{AmazonLinux, "2", []string{"yum"}, SystemdInit},
+		{AmazonLinux, "2023", []string{"dnf", "yum"}, SystemdInit},
+		{CentOS, "stream", []string{"dnf", "yum"}, SystemdInit},
+	} {
+		c.Logf("test %d: %s %s", i, t.osType, t.version)
+		m := t.osType.MetadataForVersion(t.version)
+		c.Check(m.PackageManagers, jc.DeepEquals, t.packageManagers)
+		c.Check(m.ServiceManager, gc.Equals, t.serviceManager)
+	}
+}
+
+func (s *metadataSuite) TestMetadataIsCopied(c *gc.C) {
+	m := Fedora.Metadata()
+	m.PackageManagers[0] = "rpm"
+	c.Check(Fedora.Metadata().PackageManagers, jc.DeepEquals, []string{"dnf", "yum"})
+}
+
+func (s *metadataSuite) TestPackageFormatString(c *gc.C) {
+	c.Check(DebPackageFormat.String(), gc.Equals, "deb")
+	c.Check(RPMPackageFormat.String(), gc.Equals, "rpm")
+	c.Check(PackageFormat(99).String(), gc.Equals, "unknown")
+}
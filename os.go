@@ -4,8 +4,25 @@
 // Package os provides access to operating system related configuration.
 package os
 
+// HostOS returns the OS type of the host. It panics if the OS type cannot
+// be determined; use DetectHostOS to handle that case.
 var HostOS = hostOS // for monkey patching
 
+// HostDistribution returns the distribution of the host, reporting both the
+// OS type and the distribution it was derived from. The errors are those
+// described by DetectHostOS.
+var HostDistribution = hostDistribution // for monkey patching
+
+// DetectHostOS returns the OS type of the host. On Linux an error is
+// returned that satisfies IsReleaseNotFoundError if there is no release
+// file, or IsMissingIDError if the release file has no ID. If the
+// distribution is not recognised, GenericLinux is returned along with an
+// error satisfying IsUnrecognisedDistroError. Kubernetes is returned in a
+// Kubernetes pod if enabled by SetKubernetesDetection.
+func DetectHostOS() (OSType, error) {
+	return detectHostOS()
+}
+
 type OSType int
 
 const (
@@ -17,24 +34,22 @@ const (
 	GenericLinux
 	OpenSUSE
 	Kubernetes
+	// The OS types that follow have no series of their own; the series
+	// package gives them the genericlinux series.
+	Debian
+	Fedora
+	RHEL
+	Rocky
+	AlmaLinux
+	AmazonLinux
+	Alpine
+	Arch
+	SLES
 )
 
 func (t OSType) String() string {
-	switch t {
-	case Ubuntu:
-		return "Ubuntu"
-	case Windows:
-		return "Windows"
-	case OSX:
-		return "OSX"
-	case CentOS:
-		return "CentOS"
-	case GenericLinux:
-		return "GenericLinux"
-	case OpenSUSE:
-		return "OpenSUSE"
-	case Kubernetes:
-		return "Kubernetes"
+	if info, ok := t.Info(); ok {
+		return info.Name
 	}
 	return "Unknown"
 }
@@ -50,9 +65,12 @@ func (t OSType) EquivalentTo(t2 OSType) bool {
 
 // IsLinux returns true if the OS type is a Linux variant.
 func (t OSType) IsLinux() bool {
-	switch t {
-	case Ubuntu, CentOS, GenericLinux, OpenSUSE:
-		return true
-	}
-	return false
+	info, ok := t.Info()
+	return ok && info.Linux
+}
+
+// Family returns the family that the OS type belongs to.
+func (t OSType) Family() Family {
+	info, _ := t.Info()
+	return info.Family
 }
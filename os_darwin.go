@@ -3,6 +3,10 @@
 
 package os
 
-func hostOS() OSType {
-	return OSX
+func (d *Detector) detectOS() (OSType, error) {
+	return OSX, nil
+}
+
+func (d *Detector) detectDistribution() (Distribution, error) {
+	return Distribution{OSType: OSX}, nil
 }
@@ -26,7 +26,8 @@ func (s *osSuite) TestHostOS(c *gc.C) {
 		// TODO(mjs) - this should really do more by patching out
 		// osReleaseFile and testing the corner cases.
 		switch os {
-		case Ubuntu, CentOS, GenericLinux:
+		case Ubuntu, CentOS, GenericLinux,
+			Debian, Fedora, RHEL, Rocky, AlmaLinux, AmazonLinux, Alpine, Arch, SLES:
 		case OpenSUSE:
 			c.Assert(os, gc.Equals, OpenSUSE)
 		default:
@@ -37,6 +38,20 @@ func (s *osSuite) TestHostOS(c *gc.C) {
 	}
 }
 
+func (s *osSuite) TestDetectHostOS(c *gc.C) {
+	os, err := DetectHostOS()
+	if err != nil {
+		c.Assert(err, jc.Satisfies, IsUnrecognisedDistroError)
+	}
+	c.Assert(os, gc.Equals, HostOS())
+
+	distro, err := HostDistribution()
+	if err != nil {
+		c.Assert(err, jc.Satisfies, IsUnrecognisedDistroError)
+	}
+	c.Assert(distro.OSType, gc.Equals, os)
+}
+
 func (s *osSuite) TestEquivalentTo(c *gc.C) {
 	c.Check(Ubuntu.EquivalentTo(CentOS), jc.IsTrue)
 	c.Check(Ubuntu.EquivalentTo(GenericLinux), jc.IsTrue)
@@ -45,10 +60,14 @@ func (s *osSuite) TestEquivalentTo(c *gc.C) {
 	c.Check(GenericLinux.EquivalentTo(OpenSUSE), jc.IsTrue)
 	c.Check(CentOS.EquivalentTo(CentOS), jc.IsTrue)
 	c.Check(CentOS.EquivalentTo(OpenSUSE), jc.IsTrue)
+	c.Check(Rocky.EquivalentTo(RHEL), jc.IsTrue)
+	c.Check(Debian.EquivalentTo(Ubuntu), jc.IsTrue)
+	c.Check(SLES.EquivalentTo(SLES), jc.IsTrue)
 
 	c.Check(OSX.EquivalentTo(Ubuntu), jc.IsFalse)
 	c.Check(OSX.EquivalentTo(Windows), jc.IsFalse)
 	c.Check(GenericLinux.EquivalentTo(OSX), jc.IsFalse)
+	c.Check(Alpine.EquivalentTo(Windows), jc.IsFalse)
 }
 
 func (s *osSuite) TestIsLinux(c *gc.C) {
@@ -56,8 +75,41 @@ func (s *osSuite) TestIsLinux(c *gc.C) {
 	c.Check(CentOS.IsLinux(), jc.IsTrue)
 	c.Check(GenericLinux.IsLinux(), jc.IsTrue)
 	c.Check(OpenSUSE.IsLinux(), jc.IsTrue)
+	c.Check(Debian.IsLinux(), jc.IsTrue)
+	c.Check(Fedora.IsLinux(), jc.IsTrue)
+	c.Check(RHEL.IsLinux(), jc.IsTrue)
+	c.Check(Rocky.IsLinux(), jc.IsTrue)
+	c.Check(AlmaLinux.IsLinux(), jc.IsTrue)
+	c.Check(AmazonLinux.IsLinux(), jc.IsTrue)
+	c.Check(Alpine.IsLinux(), jc.IsTrue)
+	c.Check(Arch.IsLinux(), jc.IsTrue)
+	c.Check(SLES.IsLinux(), jc.IsTrue)
 
 	c.Check(OSX.IsLinux(), jc.IsFalse)
 	c.Check(Windows.IsLinux(), jc.IsFalse)
 	c.Check(Unknown.IsLinux(), jc.IsFalse)
 }
+
+func (s *osSuite) TestString(c *gc.C) {
+	for osType, expected := range map[OSType]string{
+		Unknown:      "Unknown",
+		Ubuntu:       "Ubuntu",
+		Windows:      "Windows",
+		OSX:          "OSX",
+		CentOS:       "CentOS",
+		GenericLinux: "GenericLinux",
+		OpenSUSE:     "OpenSUSE",
+		Kubernetes:   "Kubernetes",
+		Debian:       "Debian",
+		Fedora:       "Fedora",
+		RHEL:         "RHEL",
+		Rocky:        "Rocky",
+		AlmaLinux:    "AlmaLinux",
+		AmazonLinux:  "AmazonLinux",
+		Alpine:       "Alpine",
+		Arch:         "Arch",
+		SLES:         "SLES",
+	} {
+		c.Check(osType.String(), gc.Equals, expected)
+	}
+}
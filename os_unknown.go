@@ -5,6 +5,10 @@
 
 package os
 
-func hostOS() OSType {
-	return Unknown
+func (d *Detector) detectOS() (OSType, error) {
+	return Unknown, nil
+}
+
+func (d *Detector) detectDistribution() (Distribution, error) {
+	return Distribution{OSType: Unknown}, nil
 }
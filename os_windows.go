@@ -3,6 +3,10 @@
 
 package os
 
-func hostOS() OSType {
-	return Windows
+func (d *Detector) detectOS() (OSType, error) {
+	return Windows, nil
+}
+
+func (d *Detector) detectDistribution() (Distribution, error) {
+	return Distribution{OSType: Windows}, nil
 }
@@ -0,0 +1,339 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// OSRelease holds the values read from an os-release file.
+//
+// See http://www.freedesktop.org/software/systemd/man/os-release.html.
+type OSRelease struct {
+	Name             string
+	ID               string
+	IDLike           []string
+	PrettyName       string
+	CPEName          string
+	Variant          string
+	VariantID        string
+	Version          string
+	VersionID        string
+	VersionCodename  string
+	BuildID          string
+	ImageID          string
+	ImageVersion     string
+	HomeURL          string
+	DocumentationURL string
+	SupportURL       string
+	BugReportURL     string
+	PrivacyPolicyURL string
+	SupportEnd       string
+	Logo             string
+	ANSIColor        string
+	DefaultHostname  string
+
+	// UbuntuCodename is the Ubuntu specific UBUNTU_CODENAME extension.
+	UbuntuCodename string
+
+	// Values holds every assignment in the file, keyed by variable name,
+	// including extension keys that have no dedicated field.
+	Values map[string]string
+
+	// Source is the path of the file the values were read from, if any.
+	Source string
+}
+
+// NewOSRelease returns an OSRelease holding the given os-release variables,
+// as returned by ReadOSRelease.
+func NewOSRelease(values map[string]string) *OSRelease {
+	release := &OSRelease{
+		Values: make(map[string]string, len(values)),
+	}
+	for k, v := range values {
+		release.set(k, v)
+	}
+	return release
+}
+
+// fields maps the os-release variable names onto the string fields of r.
+func (r *OSRelease) fields() map[string]*string {
+	return map[string]*string{
+		"NAME":               &r.Name,
+		"ID":                 &r.ID,
+		"PRETTY_NAME":        &r.PrettyName,
+		"CPE_NAME":           &r.CPEName,
+		"VARIANT":            &r.Variant,
+		"VARIANT_ID":         &r.VariantID,
+		"VERSION":            &r.Version,
+		"VERSION_ID":         &r.VersionID,
+		"VERSION_CODENAME":   &r.VersionCodename,
+		"BUILD_ID":           &r.BuildID,
+		"IMAGE_ID":           &r.ImageID,
+		"IMAGE_VERSION":      &r.ImageVersion,
+		"HOME_URL":           &r.HomeURL,
+		"DOCUMENTATION_URL":  &r.DocumentationURL,
+		"SUPPORT_URL":        &r.SupportURL,
+		"BUG_REPORT_URL":     &r.BugReportURL,
+		"PRIVACY_POLICY_URL": &r.PrivacyPolicyURL,
+		"SUPPORT_END":        &r.SupportEnd,
+		"LOGO":               &r.Logo,
+		"ANSI_COLOR":         &r.ANSIColor,
+		"DEFAULT_HOSTNAME":   &r.DefaultHostname,
+		"UBUNTU_CODENAME":    &r.UbuntuCodename,
+	}
+}
+
+// Value returns the value of the named variable, or an empty string if it
+// was not set.
+func (r *OSRelease) Value(key string) string {
+	return r.Values[key]
+}
+
+// Extensions returns the variables that have no dedicated field, such as
+// vendor specific keys.
+func (r *OSRelease) Extensions() map[string]string {
+	known := r.fields()
+	result := make(map[string]string)
+	for k, v := range r.Values {
+		if _, ok := known[k]; ok || k == "ID_LIKE" {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func (r *OSRelease) set(key, value string) {
+	r.Values[key] = value
+	if key == "ID_LIKE" {
+		r.IDLike = strings.Fields(value)
+		return
+	}
+	if field, ok := r.fields()[key]; ok {
+		*field = value
+	}
+}
+
+// MalformedLine describes a line of an os-release file that could not be
+// parsed.
+type MalformedLine struct {
+	// Line is the 1-based line number.
+	Line int
+	// Text is the content of the line.
+	Text string
+	// Reason describes what is wrong with the line.
+	Reason string
+}
+
+// OSReleaseSyntaxError is returned by ParseOSReleaseStrict when an
+// os-release file contains malformed lines.
+type OSReleaseSyntaxError struct {
+	Lines []MalformedLine
+}
+
+func (e *OSReleaseSyntaxError) Error() string {
+	msgs := make([]string, len(e.Lines))
+	for i, l := range e.Lines {
+		msgs[i] = fmt.Sprintf("line %d: %s: %q", l.Line, l.Reason, l.Text)
+	}
+	return "malformed os-release: " + strings.Join(msgs, "; ")
+}
+
+// ParseOSRelease parses os-release content from r. Malformed lines are
+// tolerated: lines that are not assignments are skipped and values that are
+// not correctly quoted are used with any surrounding quotes removed.
+func ParseOSRelease(r io.Reader) (*OSRelease, error) {
+	release, _, err := parseOSRelease(r, false)
+	return release, errors.Trace(err)
+}
+
+// ParseOSReleaseStrict parses os-release content from r, following the
+// os-release specification. If any line is malformed an
+// *OSReleaseSyntaxError is returned that reports every offending line.
+func ParseOSReleaseStrict(r io.Reader) (*OSRelease, error) {
+	release, malformed, err := parseOSRelease(r, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(malformed) > 0 {
+		return nil, &OSReleaseSyntaxError{Lines: malformed}
+	}
+	return release, nil
+}
+
+// ParseOSReleaseFile parses the os-release file at the given path.
+func ParseOSReleaseFile(f string) (*OSRelease, error) {
+	contents, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	release, err := ParseOSRelease(bytes.NewReader(contents))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	release.Source = f
+	return release, nil
+}
+
+// ReadOSRelease parses the information in the os-release file.
+//
+// See http://www.freedesktop.org/software/systemd/man/os-release.html.
+func ReadOSRelease(f string) (map[string]string, error) {
+	release, err := readOSRelease(f)
+	if err != nil {
+		return nil, err
+	}
+	return release.Values, nil
+}
+
+// readOSRelease parses the os-release file at the given path, which must
+// define an ID.
+func readOSRelease(f string) (*OSRelease, error) {
+	release, err := ParseOSReleaseFile(f)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := release.Values["ID"]; !ok {
+		return nil, errors.Trace(missingIDError{})
+	}
+	return release, nil
+}
+
+func parseOSRelease(r io.Reader, strict bool) (*OSRelease, []MalformedLine, error) {
+	release := NewOSRelease(nil)
+	var malformed []MalformedLine
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		text := scanner.Text()
+		line := strings.TrimSpace(text)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, reason := parseOSReleaseLine(line, strict)
+		if reason != "" {
+			malformed = append(malformed, MalformedLine{
+				Line:   lineNo,
+				Text:   text,
+				Reason: reason,
+			})
+			if strict || key == "" {
+				continue
+			}
+		}
+		release.set(key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return release, malformed, nil
+}
+
+// parseOSReleaseLine splits an assignment into its key and unquoted value.
+// If the line is malformed the reason is returned; when not strict, the key
+// and a best effort value are still returned where possible.
+func parseOSReleaseLine(line string, strict bool) (string, string, string) {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		return "", "", "missing '='"
+	}
+	key, rawValue := parts[0], parts[1]
+	if strict && (strings.TrimSpace(key) != key || strings.TrimSpace(rawValue) != rawValue) {
+		return key, "", "whitespace around '='"
+	}
+	key = strings.TrimSpace(key)
+	if !validOSReleaseKey(key) {
+		return "", "", "invalid variable name"
+	}
+	rawValue = strings.TrimSpace(rawValue)
+	value, err := unquoteOSReleaseValue(rawValue)
+	if err != nil {
+		return key, strings.Trim(rawValue, "\t '\""), err.Error()
+	}
+	return key, value, ""
+}
+
+func validOSReleaseKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, c := range key {
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// unquoteOSReleaseValue decodes a shell style value, which may be single
+// quoted, double quoted with backslash escapes, or unquoted.
+func unquoteOSReleaseValue(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	switch quote := s[0]; quote {
+	case '\'', '"':
+		end := -1
+		var b strings.Builder
+	loop:
+		for i := 1; i < len(s); i++ {
+			c := s[i]
+			switch {
+			case c == quote:
+				end = i
+				break loop
+			case c == '\\' && quote == '"':
+				if i+1 == len(s) {
+					break loop
+				}
+				switch next := s[i+1]; next {
+				case '\\', '"', '$', '`':
+					b.WriteByte(next)
+					i++
+				default:
+					b.WriteByte(c)
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if end == -1 {
+			return "", errors.New("unterminated quoted string")
+		}
+		if end != len(s)-1 {
+			return "", errors.New("unexpected characters after closing quote")
+		}
+		return b.String(), nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 == len(s) {
+				return "", errors.New("trailing backslash")
+			}
+			i++
+			b.WriteByte(s[i])
+		case '\'', '"', '$', '`':
+			return "", errors.Errorf("unescaped %q in unquoted value", c)
+		case ' ', '\t':
+			return "", errors.New("unquoted whitespace")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type osReleaseSuite struct {
+}
+
+var _ = gc.Suite(&osReleaseSuite{})
+
+const jammyOSRelease = `PRETTY_NAME="Ubuntu 22.04.3 LTS"
+NAME="Ubuntu"
+VERSION_ID="22.04"
+VERSION="22.04.3 LTS (Jammy Jellyfish)"
+VERSION_CODENAME=jammy
+ID=ubuntu
+ID_LIKE=debian
+HOME_URL="https://www.ubuntu.com/"
+SUPPORT_URL="https://help.ubuntu.com/"
+BUG_REPORT_URL="https://bugs.launchpad.net/ubuntu/"
+PRIVACY_POLICY_URL="https://www.ubuntu.com/legal/terms-and-policies/privacy-policy"
+UBUNTU_CODENAME=jammy
+`
+
+func (s *osReleaseSuite) TestParseOSRelease(c *gc.C) {
+	release, err := ParseOSRelease(strings.NewReader(jammyOSRelease))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "ubuntu")
+	c.Check(release.IDLike, jc.DeepEquals, []string{"debian"})
+	c.Check(release.Name, gc.Equals, "Ubuntu")
+	c.Check(release.PrettyName, gc.Equals, "Ubuntu 22.04.3 LTS")
+	c.Check(release.Version, gc.Equals, "22.04.3 LTS (Jammy Jellyfish)")
+	c.Check(release.VersionID, gc.Equals, "22.04")
+	c.Check(release.VersionCodename, gc.Equals, "jammy")
+	c.Check(release.UbuntuCodename, gc.Equals, "jammy")
+	c.Check(release.PrivacyPolicyURL, gc.Equals, "https://www.ubuntu.com/legal/terms-and-policies/privacy-policy")
+	c.Check(release.Value("ID_LIKE"), gc.Equals, "debian")
+	c.Check(release.Extensions(), gc.HasLen, 0)
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseIDLike(c *gc.C) {
+	release, err := ParseOSRelease(strings.NewReader(`ID="rocky"
+ID_LIKE="rhel centos fedora"
+VARIANT_ID=server
+BUILD_ID=2023-10-01
+IMAGE_ID=rocky-cloud
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "rocky")
+	c.Check(release.IDLike, jc.DeepEquals, []string{"rhel", "centos", "fedora"})
+	c.Check(release.VariantID, gc.Equals, "server")
+	c.Check(release.BuildID, gc.Equals, "2023-10-01")
+	c.Check(release.ImageID, gc.Equals, "rocky-cloud")
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseQuoting(c *gc.C) {
+	for i, test := range []struct {
+		line  string
+		value string
+	}{
+		{line: `NAME=Ubuntu`, value: "Ubuntu"},
+		{line: `NAME="Ubuntu"`, value: "Ubuntu"},
+		{line: `NAME='Ubuntu'`, value: "Ubuntu"},
+		{line: `NAME=""`, value: ""},
+		{line: `NAME=`, value: ""},
+		{line: `NAME="Foo \"Bar\" Linux"`, value: `Foo "Bar" Linux`},
+		{line: `NAME="C:\\ \$HOME \` + "`" + `"`, value: "C:\\ $HOME `"},
+		{line: `NAME="back\slash"`, value: `back\slash`},
+		{line: `NAME='it"s'`, value: `it"s`},
+		{line: `NAME=Foo\ Linux`, value: "Foo Linux"},
+		{line: `NAME="Linux # not a comment"`, value: "Linux # not a comment"},
+		{line: `NAME=a=b`, value: "a=b"},
+		{line: `  NAME="indented"  `, value: "indented"},
+		// Malformed values are still read when parsing leniently.
+		{line: `NAME= "spaced" `, value: "spaced"},
+		{line: `NAME="unterminated`, value: "unterminated"},
+	} {
+		c.Logf("test %d: %s", i, test.line)
+		release, err := ParseOSRelease(strings.NewReader("ID=test\n" + test.line + "\n"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(release.Name, gc.Equals, test.value)
+	}
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseComments(c *gc.C) {
+	release, err := ParseOSRelease(strings.NewReader(`# ID=commented
+  # NAME=indented comment
+
+ID=debian
+junk
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "debian")
+	c.Check(release.Name, gc.Equals, "")
+	c.Check(release.Values, jc.DeepEquals, map[string]string{"ID": "debian"})
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseExtensions(c *gc.C) {
+	release, err := ParseOSRelease(strings.NewReader(`ID=ubuntu
+UBUNTU_CODENAME=noble
+LOGO=ubuntu-logo
+VENDOR_NAME="Acme Corp"
+ACME_BUILD=42
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.Logo, gc.Equals, "ubuntu-logo")
+	c.Check(release.Extensions(), jc.DeepEquals, map[string]string{
+		"VENDOR_NAME": "Acme Corp",
+		"ACME_BUILD":  "42",
+	})
+	c.Check(release.Value("VENDOR_NAME"), gc.Equals, "Acme Corp")
+	c.Check(release.Value("MISSING"), gc.Equals, "")
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseStrict(c *gc.C) {
+	release, err := ParseOSReleaseStrict(strings.NewReader(jammyOSRelease))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "ubuntu")
+}
+
+func (s *osReleaseSuite) TestParseOSReleaseStrictMalformed(c *gc.C) {
+	_, err := ParseOSReleaseStrict(strings.NewReader(`# comment
+ID=ubuntu
+some junk
+VERSION_ID= "12.04"
+NAME="unterminated
+1BAD=value
+PRETTY_NAME=Ubuntu Linux
+VERSION="22.04"trailing
+HOME_URL=$HOME
+`))
+	c.Assert(err, gc.FitsTypeOf, &OSReleaseSyntaxError{})
+	c.Check(err.(*OSReleaseSyntaxError).Lines, jc.DeepEquals, []MalformedLine{
+		{Line: 3, Text: "some junk", Reason: "missing '='"},
+		{Line: 4, Text: `VERSION_ID= "12.04"`, Reason: "whitespace around '='"},
+		{Line: 5, Text: `NAME="unterminated`, Reason: "unterminated quoted string"},
+		{Line: 6, Text: "1BAD=value", Reason: "invalid variable name"},
+		{Line: 7, Text: "PRETTY_NAME=Ubuntu Linux", Reason: "unquoted whitespace"},
+		{Line: 8, Text: `VERSION="22.04"trailing`, Reason: "unexpected characters after closing quote"},
+		{Line: 9, Text: "HOME_URL=$HOME", Reason: `unescaped '$' in unquoted value`},
+	})
+	c.Check(err, gc.ErrorMatches, `malformed os-release: line 3: missing '=': "some junk"; line 4: .*`)
+}
+
+func (s *osReleaseSuite) TestReadOSRelease(c *gc.C) {
+	f := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(f, []byte(jammyOSRelease), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := ReadOSRelease(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values["ID"], gc.Equals, "ubuntu")
+	c.Check(values["VERSION_ID"], gc.Equals, "22.04")
+	c.Check(values["UBUNTU_CODENAME"], gc.Equals, "jammy")
+	c.Check(values, gc.HasLen, 12)
+}
+
+func (s *osReleaseSuite) TestReadOSReleaseMissingID(c *gc.C) {
+	f := filepath.Join(c.MkDir(), "os-release")
+	err := ioutil.WriteFile(f, []byte("NAME=Linux\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ReadOSRelease(f)
+	c.Assert(err, gc.ErrorMatches, "OS release file is missing ID")
+}
+
+func (s *osReleaseSuite) TestReadOSReleaseMissingFile(c *gc.C) {
+	_, err := ReadOSRelease(filepath.Join(c.MkDir(), "os-release"))
+	c.Assert(err, gc.ErrorMatches, "open .*")
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// osTypeAliases maps other common names onto the OS type they name. The
+// names are lower case.
+var osTypeAliases = map[string]OSType{
+	"macos":   OSX,
+	"darwin":  OSX,
+	"win":     Windows,
+	"suse":    OpenSUSE,
+	"linux":   GenericLinux,
+	"generic": GenericLinux,
+	"k8s":     Kubernetes,
+	"redhat":  RHEL,
+	"amazon":  AmazonLinux,
+	"alma":    AlmaLinux,
+}
+
+// AllOSTypes returns every known OS type other than Unknown, including
+// those added with RegisterOSType.
+func AllOSTypes() []OSType {
+	osTypesMutex.RLock()
+	defer osTypesMutex.RUnlock()
+
+	result := make([]OSType, 0, len(osTypes)-1)
+	for t := range osTypes {
+		if OSType(t) != Unknown {
+			result = append(result, OSType(t))
+		}
+	}
+	return result
+}
+
+// ParseOSType returns the OS type with the given name, ignoring case. As
+// well as the names returned by OSType.String, common aliases such as
+// "macos" and "suse" and the os-release IDs of each OS type are accepted.
+func ParseOSType(s string) (OSType, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+
+	osTypesMutex.RLock()
+	defer osTypesMutex.RUnlock()
+
+	for t, info := range osTypes {
+		if strings.ToLower(info.Name) == name {
+			return OSType(t), nil
+		}
+	}
+	if t, ok := osTypeAliases[name]; ok {
+		return t, nil
+	}
+	if t, ok := osReleaseIDs[name]; ok {
+		return t, nil
+	}
+	return Unknown, errors.NotValidf("OS type %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler. OS types are marshalled
+// as their name, which is also used by encoding/json.
+func (t OSType) MarshalText() ([]byte, error) {
+	info, ok := t.Info()
+	if !ok {
+		return nil, errors.NotValidf("OS type %d", int(t))
+	}
+	return []byte(info.Name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any name
+// understood by ParseOSType.
+func (t *OSType) UnmarshalText(text []byte) error {
+	parsed, err := ParseOSType(string(text))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	*t = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. As well as names, the numeric
+// values previously written by encoding/json are accepted.
+func (t *OSType) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		return errors.Trace(t.setNumeric(n))
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.NotValidf("OS type %s", data)
+	}
+	return errors.Trace(t.UnmarshalText([]byte(s)))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2
+// and gopkg.in/yaml.v3.
+func (t OSType) MarshalYAML() (interface{}, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return string(text), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of
+// gopkg.in/yaml.v2. As with UnmarshalJSON, numeric values are accepted.
+func (t *OSType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n int
+	if err := unmarshal(&n); err == nil {
+		return errors.Trace(t.setNumeric(n))
+	}
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(t.UnmarshalText([]byte(s)))
+}
+
+func (t *OSType) setNumeric(n int) error {
+	if _, ok := OSType(n).Info(); !ok {
+		return errors.NotValidf("OS type %d", n)
+	}
+	*t = OSType(n)
+	return nil
+}
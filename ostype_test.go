@@ -0,0 +1,136 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"encoding/json"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type osTypeSuite struct {
+}
+
+var _ = gc.Suite(&osTypeSuite{})
+
+func (s *osTypeSuite) TestParseOSType(c *gc.C) {
+	for name, expected := range map[string]OSType{
+		"Ubuntu":        Ubuntu,
+		"ubuntu":        Ubuntu,
+		" UBUNTU ":      Ubuntu,
+		"osx":           OSX,
+		"macos":         OSX,
+		"MacOS":         OSX,
+		"darwin":        OSX,
+		"windows":       Windows,
+		"suse":          OpenSUSE,
+		"opensuse":      OpenSUSE,
+		"opensuse-leap": OpenSUSE,
+		"genericlinux":  GenericLinux,
+		"k8s":           Kubernetes,
+		"kubernetes":    Kubernetes,
+		"rhel":          RHEL,
+		"redhat":        RHEL,
+		"amzn":          AmazonLinux,
+		"sles_sap":      SLES,
+		"unknown":       Unknown,
+	} {
+		t, err := ParseOSType(name)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(t, gc.Equals, expected, gc.Commentf("%q", name))
+	}
+}
+
+func (s *osTypeSuite) TestParseOSTypeInvalid(c *gc.C) {
+	_, err := ParseOSType("plan9")
+	c.Assert(err, gc.ErrorMatches, `OS type "plan9" not valid`)
+}
+
+func (s *osTypeSuite) TestParseOSTypeRoundTrip(c *gc.C) {
+	for _, t := range AllOSTypes() {
+		parsed, err := ParseOSType(t.String())
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(parsed, gc.Equals, t)
+	}
+}
+
+func (s *osTypeSuite) TestAllOSTypes(c *gc.C) {
+	all := AllOSTypes()
+	c.Check(all, gc.HasLen, len(osTypes)-1)
+	c.Check(all[0], gc.Equals, Ubuntu)
+	for _, t := range all {
+		c.Check(t, gc.Not(gc.Equals), Unknown)
+	}
+}
+
+type osTypeDoc struct {
+	OS OSType `json:"os" yaml:"os"`
+}
+
+func (s *osTypeSuite) TestText(c *gc.C) {
+	text, err := OpenSUSE.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(text), gc.Equals, "OpenSUSE")
+
+	var t OSType
+	err = t.UnmarshalText([]byte("macos"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t, gc.Equals, OSX)
+
+	_, err = OSType(-1).MarshalText()
+	c.Check(err, gc.ErrorMatches, "OS type -1 not valid")
+}
+
+func (s *osTypeSuite) TestJSON(c *gc.C) {
+	data, err := json.Marshal(osTypeDoc{OS: CentOS})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"os":"CentOS"}`)
+
+	var doc osTypeDoc
+	err = json.Unmarshal(data, &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, CentOS)
+
+	err = json.Unmarshal([]byte(`{"os":"suse"}`), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, OpenSUSE)
+
+	// Numeric values written by earlier versions are still read.
+	err = json.Unmarshal([]byte(`{"os":1}`), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, Ubuntu)
+
+	err = json.Unmarshal([]byte(`{"os":"plan9"}`), &doc)
+	c.Check(err, gc.ErrorMatches, `OS type "plan9" not valid`)
+	err = json.Unmarshal([]byte(`{"os":999}`), &doc)
+	c.Check(err, gc.ErrorMatches, `OS type 999 not valid`)
+
+	data, err = json.Marshal(map[OSType]bool{Windows: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"Windows":true}`)
+}
+
+func (s *osTypeSuite) TestYAML(c *gc.C) {
+	data, err := yaml.Marshal(osTypeDoc{OS: AmazonLinux})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "os: AmazonLinux\n")
+
+	var doc osTypeDoc
+	err = yaml.Unmarshal(data, &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, AmazonLinux)
+
+	err = yaml.Unmarshal([]byte("os: MacOS\n"), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, OSX)
+
+	err = yaml.Unmarshal([]byte("os: 4\n"), &doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc.OS, gc.Equals, CentOS)
+
+	err = yaml.Unmarshal([]byte("os: plan9\n"), &doc)
+	c.Check(err, gc.ErrorMatches, `OS type "plan9" not valid`)
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// Family identifies a group of operating systems that share packaging and
+// system conventions.
+type Family int
+
+const (
+	UnknownFamily Family = iota
+	DebianFamily
+	RedHatFamily
+	SUSEFamily
+	ArchFamily
+	AlpineFamily
+	DarwinFamily
+	WindowsFamily
+)
+
+func (f Family) String() string {
+	switch f {
+	case DebianFamily:
+		return "Debian"
+	case RedHatFamily:
+		return "RedHat"
+	case SUSEFamily:
+		return "SUSE"
+	case ArchFamily:
+		return "Arch"
+	case AlpineFamily:
+		return "Alpine"
+	case DarwinFamily:
+		return "Darwin"
+	case WindowsFamily:
+		return "Windows"
+	}
+	return "Unknown"
+}
+
+// SeriesResolver maps between os-release data and the series of an OS type.
+type SeriesResolver interface {
+	// SeriesFromOSRelease returns the series described by the os-release
+	// data.
+	SeriesFromOSRelease(release *OSRelease) (string, error)
+
+	// Series returns every series of the OS type.
+	Series() []string
+}
+
+// OSTypeInfo describes an OS type.
+type OSTypeInfo struct {
+	// Name is the name returned by OSType.String.
+	Name string
+
+	// IDs are the os-release IDs that identify the OS type. They are
+	// matched against both ID and ID_LIKE.
+	IDs []string
+
+	// Linux indicates whether the OS type is a Linux variant.
+	Linux bool
+
+	// Family is the family that the OS type belongs to.
+	Family Family
+
+	// Series resolves the series of the OS type. The series of the
+	// built in OS types are resolved by the series package, so it is nil
+	// for them.
+	Series SeriesResolver
+}
+
+var (
+	osTypesMutex sync.RWMutex
+
+	// osTypes holds the information for every OS type, indexed by OSType.
+	osTypes = []OSTypeInfo{
+		Unknown:      {Name: "Unknown"},
+		Ubuntu:       {Name: "Ubuntu", IDs: []string{"ubuntu", "ubuntu-core"}, Linux: true, Family: DebianFamily},
+		Windows:      {Name: "Windows", Family: WindowsFamily},
+		OSX:          {Name: "OSX", Family: DarwinFamily},
+		CentOS:       {Name: "CentOS", IDs: []string{"centos"}, Linux: true, Family: RedHatFamily},
+		GenericLinux: {Name: "GenericLinux", Linux: true},
+		OpenSUSE:     {Name: "OpenSUSE", IDs: []string{"opensuse", "opensuse-leap", "opensuse-tumbleweed", "suse"}, Linux: true, Family: SUSEFamily},
+		Kubernetes:   {Name: "Kubernetes"},
+		Debian:       {Name: "Debian", IDs: []string{"debian"}, Linux: true, Family: DebianFamily},
+		Fedora:       {Name: "Fedora", IDs: []string{"fedora"}, Linux: true, Family: RedHatFamily},
+		RHEL:         {Name: "RHEL", IDs: []string{"rhel"}, Linux: true, Family: RedHatFamily},
+		Rocky:        {Name: "Rocky", IDs: []string{"rocky"}, Linux: true, Family: RedHatFamily},
+		AlmaLinux:    {Name: "AlmaLinux", IDs: []string{"almalinux"}, Linux: true, Family: RedHatFamily},
+		AmazonLinux:  {Name: "AmazonLinux", IDs: []string{"amzn"}, Linux: true, Family: RedHatFamily},
+		Alpine:       {Name: "Alpine", IDs: []string{"alpine"}, Linux: true, Family: AlpineFamily},
+		Arch:         {Name: "Arch", IDs: []string{"arch"}, Linux: true, Family: ArchFamily},
+		SLES:         {Name: "SLES", IDs: []string{"sles", "sles_sap"}, Linux: true, Family: SUSEFamily},
+	}
+
+	// builtinOSTypes is the number of OS types defined by this package.
+	builtinOSTypes = len(osTypes)
+
+	// osReleaseIDs maps os-release IDs onto the OS type they identify.
+	osReleaseIDs = indexOSReleaseIDs(osTypes)
+)
+
+func indexOSReleaseIDs(types []OSTypeInfo) map[string]OSType {
+	index := make(map[string]OSType)
+	for t, info := range types {
+		for _, id := range info.IDs {
+			index[id] = OSType(t)
+		}
+	}
+	return index
+}
+
+// RegisterOSType registers a new OS type described by info and returns it.
+// OS types are expected to be registered during initialisation, before the
+// host OS is first detected.
+func RegisterOSType(info OSTypeInfo) (OSType, error) {
+	if info.Name == "" {
+		return Unknown, errors.NotValidf("empty OS type name")
+	}
+
+	osTypesMutex.Lock()
+	defer osTypesMutex.Unlock()
+
+	for _, existing := range osTypes {
+		if strings.EqualFold(existing.Name, info.Name) {
+			return Unknown, errors.AlreadyExistsf("OS type %q", info.Name)
+		}
+	}
+	ids := make([]string, len(info.IDs))
+	for i, id := range info.IDs {
+		ids[i] = strings.ToLower(id)
+		if existing, ok := osReleaseIDs[ids[i]]; ok {
+			return Unknown, errors.AlreadyExistsf("os-release ID %q for OS type %q", id, osTypes[existing].Name)
+		}
+	}
+	info.IDs = ids
+
+	t := OSType(len(osTypes))
+	osTypes = append(osTypes, info)
+	for _, id := range info.IDs {
+		osReleaseIDs[id] = t
+	}
+	return t, nil
+}
+
+// UnregisterOSType removes the OS type t, added with RegisterOSType, so
+// that tests can restore the registry they started with. OS types are
+// identified by the order they were registered in, so only the most
+// recently registered OS type can be removed.
+func UnregisterOSType(t OSType) error {
+	osTypesMutex.Lock()
+	defer osTypesMutex.Unlock()
+
+	if int(t) < builtinOSTypes || int(t) != len(osTypes)-1 {
+		return errors.NotValidf("unregistering OS type %d", int(t))
+	}
+	for _, id := range osTypes[t].IDs {
+		delete(osReleaseIDs, id)
+	}
+	osTypes = osTypes[:t]
+	return nil
+}
+
+// RegisteredOSTypes returns the OS types added with RegisterOSType, in the
+// order they were registered.
+func RegisteredOSTypes() []OSType {
+	osTypesMutex.RLock()
+	defer osTypesMutex.RUnlock()
+
+	var result []OSType
+	for t := builtinOSTypes; t < len(osTypes); t++ {
+		result = append(result, OSType(t))
+	}
+	return result
+}
+
+// Info returns the information describing the OS type, and whether the OS
+// type is known.
+func (t OSType) Info() (OSTypeInfo, bool) {
+	osTypesMutex.RLock()
+	defer osTypesMutex.RUnlock()
+
+	if t < 0 || int(t) >= len(osTypes) {
+		return OSTypeInfo{}, false
+	}
+	return osTypes[t], true
+}
+
+// osTypeForOSReleaseID returns the OS type identified by the given
+// os-release ID.
+func osTypeForOSReleaseID(id string) (OSType, bool) {
+	osTypesMutex.RLock()
+	defer osTypesMutex.RUnlock()
+
+	t, ok := osReleaseIDs[id]
+	return t, ok
+}
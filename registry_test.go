@@ -0,0 +1,142 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type registrySuite struct {
+	origTypes []OSTypeInfo
+	origIDs   map[string]OSType
+}
+
+var _ = gc.Suite(&registrySuite{})
+
+func (s *registrySuite) SetUpTest(c *gc.C) {
+	s.origTypes = append([]OSTypeInfo(nil), osTypes...)
+	s.origIDs = indexOSReleaseIDs(osTypes)
+}
+
+func (s *registrySuite) TearDownTest(c *gc.C) {
+	osTypes = s.origTypes
+	osReleaseIDs = s.origIDs
+}
+
+type stubResolver struct{}
+
+func (stubResolver) SeriesFromOSRelease(release *OSRelease) (string, error) {
+	return "mint" + release.VersionID, nil
+}
+
+func (stubResolver) Series() []string {
+	return []string{"mint21"}
+}
+
+func (s *registrySuite) TestRegisterOSType(c *gc.C) {
+	mint, err := RegisterOSType(OSTypeInfo{
+		Name:   "Mint",
+		IDs:    []string{"LinuxMint"},
+		Linux:  true,
+		Family: DebianFamily,
+		Series: stubResolver{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mint.String(), gc.Equals, "Mint")
+	c.Check(mint.IsLinux(), jc.IsTrue)
+	c.Check(mint.EquivalentTo(Ubuntu), jc.IsTrue)
+	c.Check(mint.EquivalentTo(Windows), jc.IsFalse)
+	c.Check(mint.Family(), gc.Equals, DebianFamily)
+	c.Check(RegisteredOSTypes(), jc.DeepEquals, []OSType{mint})
+
+	info, ok := mint.Info()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(info.IDs, jc.DeepEquals, []string{"linuxmint"})
+	c.Check(info.Series.Series(), jc.DeepEquals, []string{"mint21"})
+
+	release, err := ParseOSRelease(strings.NewReader("ID=linuxmint\nID_LIKE=\"ubuntu debian\""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(DistributionFromOSRelease(release), jc.DeepEquals, Distribution{
+		OSType:    mint,
+		ID:        "linuxmint",
+		MatchedID: "linuxmint",
+	})
+
+	// Derivatives of the registered OS type are matched through ID_LIKE.
+	release, err = ParseOSRelease(strings.NewReader("ID=lmde\nID_LIKE=linuxmint"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(DistributionFromOSRelease(release).OSType, gc.Equals, mint)
+}
+
+func (s *registrySuite) TestUnregisterOSType(c *gc.C) {
+	mint, err := RegisterOSType(OSTypeInfo{Name: "Mint", IDs: []string{"linuxmint"}})
+	c.Assert(err, jc.ErrorIsNil)
+	pop, err := RegisterOSType(OSTypeInfo{Name: "Pop", IDs: []string{"pop"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = UnregisterOSType(mint)
+	c.Check(err, gc.ErrorMatches, `unregistering OS type \d+ not valid`)
+	err = UnregisterOSType(Ubuntu)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	err = UnregisterOSType(pop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(RegisteredOSTypes(), jc.DeepEquals, []OSType{mint})
+	_, ok := osTypeForOSReleaseID("pop")
+	c.Check(ok, jc.IsFalse)
+
+	err = UnregisterOSType(mint)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(RegisteredOSTypes(), gc.HasLen, 0)
+
+	// The name and IDs can be registered again.
+	_, err = RegisterOSType(OSTypeInfo{Name: "Mint", IDs: []string{"linuxmint"}})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *registrySuite) TestRegisterOSTypeEmptyName(c *gc.C) {
+	_, err := RegisterOSType(OSTypeInfo{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *registrySuite) TestRegisterOSTypeDuplicateName(c *gc.C) {
+	_, err := RegisterOSType(OSTypeInfo{Name: "ubuntu"})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `OS type "ubuntu" already exists`)
+}
+
+func (s *registrySuite) TestRegisterOSTypeDuplicateID(c *gc.C) {
+	_, err := RegisterOSType(OSTypeInfo{Name: "Rocky2", IDs: []string{"rocky"}})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `os-release ID "rocky" for OS type "Rocky" already exists`)
+	c.Assert(RegisteredOSTypes(), gc.HasLen, 0)
+}
+
+func (s *registrySuite) TestBuiltinInfo(c *gc.C) {
+	info, ok := Rocky.Info()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(info.Name, gc.Equals, "Rocky")
+	c.Check(info.Family, gc.Equals, RedHatFamily)
+	c.Check(info.Series, gc.IsNil)
+
+	_, ok = OSType(1000).Info()
+	c.Check(ok, jc.IsFalse)
+	c.Check(OSType(1000).String(), gc.Equals, "Unknown")
+	c.Check(OSType(1000).IsLinux(), jc.IsFalse)
+}
+
+func (s *registrySuite) TestFamily(c *gc.C) {
+	c.Check(Ubuntu.Family(), gc.Equals, DebianFamily)
+	c.Check(CentOS.Family(), gc.Equals, RedHatFamily)
+	c.Check(SLES.Family(), gc.Equals, SUSEFamily)
+	c.Check(OSX.Family(), gc.Equals, DarwinFamily)
+	c.Check(Windows.Family(), gc.Equals, WindowsFamily)
+	c.Check(GenericLinux.Family(), gc.Equals, UnknownFamily)
+	c.Check(RedHatFamily.String(), gc.Equals, "RedHat")
+	c.Check(UnknownFamily.String(), gc.Equals, "Unknown")
+}
@@ -0,0 +1,307 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"bytes"
+	"io/fs"
+	"io/ioutil"
+	goos "os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ReleaseFormat identifies the format of a file that describes a Linux
+// distribution.
+type ReleaseFormat int
+
+const (
+	// OSReleaseFormat is the freedesktop os-release format.
+	OSReleaseFormat ReleaseFormat = iota
+	// LSBReleaseFormat is the format of /etc/lsb-release.
+	LSBReleaseFormat
+	// RedHatReleaseFormat is the single line format of /etc/redhat-release
+	// and /etc/centos-release.
+	RedHatReleaseFormat
+	// SuSEReleaseFormat is the format of the legacy /etc/SuSE-release.
+	SuSEReleaseFormat
+	// DebianVersionFormat is the format of /etc/debian_version.
+	DebianVersionFormat
+)
+
+// ReleaseFile describes a file that identifies a Linux distribution.
+type ReleaseFile struct {
+	Path   string
+	Format ReleaseFormat
+}
+
+// ReleaseFiles returns the files consulted, in order, to identify the Linux
+// distribution of a host. /etc/os-release is preferred; the remainder are
+// found on minimal containers and older images that do not provide it.
+func ReleaseFiles() []ReleaseFile {
+	return []ReleaseFile{
+		{Path: "/etc/os-release", Format: OSReleaseFormat},
+		{Path: "/usr/lib/os-release", Format: OSReleaseFormat},
+		{Path: "/etc/lsb-release", Format: LSBReleaseFormat},
+		{Path: "/etc/redhat-release", Format: RedHatReleaseFormat},
+		{Path: "/etc/centos-release", Format: RedHatReleaseFormat},
+		{Path: "/etc/SuSE-release", Format: SuSEReleaseFormat},
+		{Path: "/etc/debian_version", Format: DebianVersionFormat},
+	}
+}
+
+// ReadRelease returns the os-release data from the first of the given files
+// that exists and describes a distribution. Files that are not in the
+// os-release format are translated into the equivalent os-release variables.
+// The Source of the result names the file that was used.
+//
+// If none of the files can be used, the error from the first file that
+// exists is returned, or an error satisfying IsReleaseNotFoundError if none
+// of them exist.
+func ReadRelease(files []ReleaseFile) (*OSRelease, error) {
+	return readRelease(ioutil.ReadFile, files)
+}
+
+// ReadReleaseFS is like ReadRelease, but reads the files from fsys. The
+// paths of the files are taken to be relative to the root of fsys, so that
+// the release of a mounted image or chroot can be read using ReleaseFiles.
+func ReadReleaseFS(fsys fs.FS, files []ReleaseFile) (*OSRelease, error) {
+	return readRelease(func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, fsPath(name))
+	}, files)
+}
+
+// fsPath converts an absolute path into one that is valid for an fs.FS
+// rooted at "/".
+func fsPath(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+func readRelease(readFile func(string) ([]byte, error), files []ReleaseFile) (*OSRelease, error) {
+	var firstErr error
+	for _, f := range files {
+		contents, err := readFile(f.Path)
+		if goos.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		release, err := parseReleaseFile(contents, f.Format)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = errors.Trace(err)
+			}
+			continue
+		}
+		release.Source = f.Path
+		return release, nil
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return nil, errors.Trace(releaseNotFoundError(paths))
+}
+
+func parseReleaseFile(contents []byte, format ReleaseFormat) (*OSRelease, error) {
+	var (
+		release *OSRelease
+		err     error
+	)
+	switch format {
+	case OSReleaseFormat:
+		release, err = ParseOSRelease(bytes.NewReader(contents))
+	case LSBReleaseFormat:
+		release, err = parseLSBRelease(contents)
+	case RedHatReleaseFormat:
+		release, err = parseRedHatRelease(contents)
+	case SuSEReleaseFormat:
+		release, err = parseSuSERelease(contents)
+	case DebianVersionFormat:
+		release, err = parseDebianVersion(contents)
+	default:
+		return nil, errors.NotValidf("release file format %d", format)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, ok := release.Values["ID"]; !ok {
+		return nil, errors.Trace(missingIDError{})
+	}
+	return release, nil
+}
+
+// parseLSBRelease translates the DISTRIB_* variables of lsb-release.
+func parseLSBRelease(contents []byte) (*OSRelease, error) {
+	lsb, err := ParseOSRelease(bytes.NewReader(contents))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(map[string]string)
+	if id := lsb.Value("DISTRIB_ID"); id != "" {
+		values["ID"] = strings.ToLower(id)
+		values["NAME"] = id
+	}
+	if version := lsb.Value("DISTRIB_RELEASE"); version != "" {
+		values["VERSION_ID"] = version
+	}
+	if codename := lsb.Value("DISTRIB_CODENAME"); codename != "" {
+		values["VERSION_CODENAME"] = codename
+		if values["ID"] == "ubuntu" {
+			values["UBUNTU_CODENAME"] = codename
+		}
+	}
+	if description := lsb.Value("DISTRIB_DESCRIPTION"); description != "" {
+		values["PRETTY_NAME"] = description
+	}
+	return NewOSRelease(values), nil
+}
+
+// redHatReleaseRE matches lines such as
+// "CentOS Linux release 7.9.2009 (Core)".
+var redHatReleaseRE = regexp.MustCompile(`^(.+?) release (\S+)(?: \((.*)\))?`)
+
+// redHatReleaseIDs maps the distribution names used in redhat-release onto
+// their os-release ID and ID_LIKE.
+var redHatReleaseIDs = []struct {
+	prefix string
+	id     string
+	idLike string
+}{
+	{prefix: "CentOS", id: "centos", idLike: "rhel fedora"},
+	{prefix: "Red Hat Enterprise Linux", id: "rhel", idLike: "fedora"},
+	{prefix: "Rocky Linux", id: "rocky", idLike: "rhel centos fedora"},
+	{prefix: "AlmaLinux", id: "almalinux", idLike: "rhel centos fedora"},
+	{prefix: "Fedora", id: "fedora"},
+	{prefix: "Amazon Linux", id: "amzn", idLike: "centos rhel fedora"},
+}
+
+// parseRedHatRelease translates the single line of redhat-release or
+// centos-release.
+func parseRedHatRelease(contents []byte) (*OSRelease, error) {
+	line := strings.TrimSpace(strings.SplitN(string(contents), "\n", 2)[0])
+	m := redHatReleaseRE.FindStringSubmatch(line)
+	if m == nil {
+		return nil, errors.NotValidf("release %q", line)
+	}
+	name, version, codename := m[1], m[2], m[3]
+
+	values := map[string]string{
+		"NAME":        name,
+		"PRETTY_NAME": line,
+		"VERSION":     version,
+		"ID":          strings.ToLower(strings.Fields(name)[0]),
+		"ID_LIKE":     "rhel fedora",
+	}
+	if codename != "" {
+		values["VERSION"] += " (" + codename + ")"
+	}
+	for _, known := range redHatReleaseIDs {
+		if strings.HasPrefix(name, known.prefix) {
+			values["ID"] = known.id
+			values["ID_LIKE"] = known.idLike
+			break
+		}
+	}
+	if values["ID_LIKE"] == "" {
+		delete(values, "ID_LIKE")
+	}
+
+	// CentOS reports only the major version in os-release, other
+	// distributions report the major and minor version.
+	parts := strings.Split(version, ".")
+	switch {
+	case values["ID"] == "centos":
+		values["VERSION_ID"] = parts[0]
+	case len(parts) > 2:
+		values["VERSION_ID"] = strings.Join(parts[:2], ".")
+	default:
+		values["VERSION_ID"] = version
+	}
+	return NewOSRelease(values), nil
+}
+
+// parseSuSERelease translates the legacy SuSE-release file, which names the
+// distribution and version on the first line, followed by VERSION and
+// PATCHLEVEL assignments.
+func parseSuSERelease(contents []byte) (*OSRelease, error) {
+	lines := strings.SplitN(string(contents), "\n", 2)
+	name := strings.TrimSpace(lines[0])
+	if i := strings.Index(name, " ("); i != -1 {
+		name = name[:i]
+	}
+	// Drop the version that follows the name, e.g. "openSUSE 42.3".
+	words := strings.Fields(name)
+	for len(words) > 1 {
+		if c := words[len(words)-1][0]; c < '0' || c > '9' {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+	name = strings.Join(words, " ")
+	if name == "" {
+		return nil, errors.NotValidf("empty SuSE release")
+	}
+	values := map[string]string{
+		"NAME":    name,
+		"ID":      "sles",
+		"ID_LIKE": "suse",
+	}
+	if strings.HasPrefix(strings.ToLower(name), "opensuse") {
+		values["ID"] = "opensuse"
+	}
+	if len(lines) > 1 {
+		for _, line := range strings.Split(lines[1], "\n") {
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			switch key {
+			case "VERSION":
+				values["VERSION_ID"] = value
+			case "PATCHLEVEL":
+				values["PATCHLEVEL"] = value
+			case "CODENAME":
+				values["VERSION_CODENAME"] = value
+			}
+		}
+	}
+	if patch := values["PATCHLEVEL"]; patch != "" && patch != "0" && !strings.Contains(values["VERSION_ID"], ".") {
+		values["VERSION_ID"] += "." + patch
+	}
+	delete(values, "PATCHLEVEL")
+	values["PRETTY_NAME"] = strings.TrimSpace(name + " " + values["VERSION_ID"])
+	return NewOSRelease(values), nil
+}
+
+// parseDebianVersion translates debian_version, which holds either a
+// version such as "12.4" or a codename such as "bookworm/sid".
+func parseDebianVersion(contents []byte) (*OSRelease, error) {
+	version := strings.TrimSpace(string(contents))
+	if version == "" {
+		return nil, errors.NotValidf("empty Debian version")
+	}
+	values := map[string]string{
+		"NAME":        "Debian GNU/Linux",
+		"ID":          "debian",
+		"PRETTY_NAME": "Debian GNU/Linux " + version,
+	}
+	if c := version[0]; c >= '0' && c <= '9' {
+		values["VERSION_ID"] = strings.Split(version, ".")[0]
+	} else {
+		values["VERSION_CODENAME"] = strings.Split(version, "/")[0]
+	}
+	return NewOSRelease(values), nil
+}
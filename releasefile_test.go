@@ -0,0 +1,227 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type releaseFileSuite struct {
+}
+
+var _ = gc.Suite(&releaseFileSuite{})
+
+func (s *releaseFileSuite) TestParseReleaseFile(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		format   ReleaseFormat
+		contents string
+		expected map[string]string
+		err      string
+	}{{
+		message: "lsb-release",
+		format:  LSBReleaseFormat,
+		contents: `DISTRIB_ID=Ubuntu
+DISTRIB_RELEASE=22.04
+DISTRIB_CODENAME=jammy
+DISTRIB_DESCRIPTION="Ubuntu 22.04.3 LTS"
+`,
+		expected: map[string]string{
+			"ID":               "ubuntu",
+			"NAME":             "Ubuntu",
+			"VERSION_ID":       "22.04",
+			"VERSION_CODENAME": "jammy",
+			"UBUNTU_CODENAME":  "jammy",
+			"PRETTY_NAME":      "Ubuntu 22.04.3 LTS",
+		},
+	}, {
+		message:  "lsb-release missing DISTRIB_ID",
+		format:   LSBReleaseFormat,
+		contents: "DISTRIB_RELEASE=22.04\n",
+		err:      "OS release file is missing ID",
+	}, {
+		message:  "centos 7",
+		format:   RedHatReleaseFormat,
+		contents: "CentOS Linux release 7.9.2009 (Core)\n",
+		expected: map[string]string{
+			"ID":          "centos",
+			"ID_LIKE":     "rhel fedora",
+			"NAME":        "CentOS Linux",
+			"VERSION":     "7.9.2009 (Core)",
+			"VERSION_ID":  "7",
+			"PRETTY_NAME": "CentOS Linux release 7.9.2009 (Core)",
+		},
+	}, {
+		message:  "rhel 8",
+		format:   RedHatReleaseFormat,
+		contents: "Red Hat Enterprise Linux release 8.9 (Ootpa)\n",
+		expected: map[string]string{
+			"ID":          "rhel",
+			"ID_LIKE":     "fedora",
+			"NAME":        "Red Hat Enterprise Linux",
+			"VERSION":     "8.9 (Ootpa)",
+			"VERSION_ID":  "8.9",
+			"PRETTY_NAME": "Red Hat Enterprise Linux release 8.9 (Ootpa)",
+		},
+	}, {
+		message:  "rocky",
+		format:   RedHatReleaseFormat,
+		contents: "Rocky Linux release 9.3 (Blue Onyx)\n",
+		expected: map[string]string{
+			"ID":          "rocky",
+			"ID_LIKE":     "rhel centos fedora",
+			"NAME":        "Rocky Linux",
+			"VERSION":     "9.3 (Blue Onyx)",
+			"VERSION_ID":  "9.3",
+			"PRETTY_NAME": "Rocky Linux release 9.3 (Blue Onyx)",
+		},
+	}, {
+		message:  "fedora",
+		format:   RedHatReleaseFormat,
+		contents: "Fedora release 39 (Thirty Nine)\n",
+		expected: map[string]string{
+			"ID":          "fedora",
+			"NAME":        "Fedora",
+			"VERSION":     "39 (Thirty Nine)",
+			"VERSION_ID":  "39",
+			"PRETTY_NAME": "Fedora release 39 (Thirty Nine)",
+		},
+	}, {
+		message:  "unknown rhel derivative",
+		format:   RedHatReleaseFormat,
+		contents: "EuroLinux release 9.2.1 (Stockholm)\n",
+		expected: map[string]string{
+			"ID":          "eurolinux",
+			"ID_LIKE":     "rhel fedora",
+			"NAME":        "EuroLinux",
+			"VERSION":     "9.2.1 (Stockholm)",
+			"VERSION_ID":  "9.2",
+			"PRETTY_NAME": "EuroLinux release 9.2.1 (Stockholm)",
+		},
+	}, {
+		message:  "malformed redhat-release",
+		format:   RedHatReleaseFormat,
+		contents: "garbage\n",
+		err:      `release "garbage" not valid`,
+	}, {
+		message:  "opensuse",
+		format:   SuSEReleaseFormat,
+		contents: "openSUSE 42.3 (x86_64)\nVERSION = 42.3\nCODENAME = Malachite\n# /etc/SuSE-release is deprecated\n",
+		expected: map[string]string{
+			"ID":               "opensuse",
+			"ID_LIKE":          "suse",
+			"NAME":             "openSUSE",
+			"VERSION_ID":       "42.3",
+			"VERSION_CODENAME": "Malachite",
+			"PRETTY_NAME":      "openSUSE 42.3",
+		},
+	}, {
+		message:  "sles",
+		format:   SuSEReleaseFormat,
+		contents: "SUSE Linux Enterprise Server 12 (x86_64)\nVERSION = 12\nPATCHLEVEL = 3\n",
+		expected: map[string]string{
+			"ID":          "sles",
+			"ID_LIKE":     "suse",
+			"NAME":        "SUSE Linux Enterprise Server",
+			"VERSION_ID":  "12.3",
+			"PRETTY_NAME": "SUSE Linux Enterprise Server 12.3",
+		},
+	}, {
+		message:  "debian version",
+		format:   DebianVersionFormat,
+		contents: "12.4\n",
+		expected: map[string]string{
+			"ID":          "debian",
+			"NAME":        "Debian GNU/Linux",
+			"VERSION_ID":  "12",
+			"PRETTY_NAME": "Debian GNU/Linux 12.4",
+		},
+	}, {
+		message:  "debian testing",
+		format:   DebianVersionFormat,
+		contents: "trixie/sid\n",
+		expected: map[string]string{
+			"ID":               "debian",
+			"NAME":             "Debian GNU/Linux",
+			"VERSION_CODENAME": "trixie",
+			"PRETTY_NAME":      "Debian GNU/Linux trixie/sid",
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		release, err := parseReleaseFile([]byte(test.contents), test.format)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(release.Values, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *releaseFileSuite) TestReadRelease(c *gc.C) {
+	d := c.MkDir()
+	broken := filepath.Join(d, "os-release")
+	err := ioutil.WriteFile(broken, []byte("NAME=broken\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	redhat := filepath.Join(d, "redhat-release")
+	err = ioutil.WriteFile(redhat, []byte("Rocky Linux release 9.3 (Blue Onyx)\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	release, err := ReadRelease([]ReleaseFile{
+		{Path: filepath.Join(d, "missing"), Format: OSReleaseFormat},
+		{Path: broken, Format: OSReleaseFormat},
+		{Path: redhat, Format: RedHatReleaseFormat},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "rocky")
+	c.Check(release.Source, gc.Equals, redhat)
+
+	distro := DistributionFromOSRelease(release)
+	c.Check(distro.OSType, gc.Equals, Rocky)
+	c.Check(distro.Source, gc.Equals, redhat)
+}
+
+func (s *releaseFileSuite) TestReadReleaseFirstError(c *gc.C) {
+	d := c.MkDir()
+	broken := filepath.Join(d, "os-release")
+	err := ioutil.WriteFile(broken, []byte("NAME=broken\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ReadRelease([]ReleaseFile{
+		{Path: broken, Format: OSReleaseFormat},
+		{Path: filepath.Join(d, "lsb-release"), Format: LSBReleaseFormat},
+	})
+	c.Assert(err, gc.ErrorMatches, "OS release file is missing ID")
+	c.Assert(err, jc.Satisfies, IsMissingIDError)
+}
+
+func (s *releaseFileSuite) TestReadReleaseNotFound(c *gc.C) {
+	d := c.MkDir()
+	_, err := ReadRelease([]ReleaseFile{
+		{Path: filepath.Join(d, "os-release"), Format: OSReleaseFormat},
+	})
+	c.Assert(err, jc.Satisfies, IsReleaseNotFoundError)
+	c.Assert(err, gc.ErrorMatches, `release file not found \(tried .*os-release\)`)
+}
+
+func (s *releaseFileSuite) TestReadReleaseFS(c *gc.C) {
+	release, err := ReadReleaseFS(fstest.MapFS{
+		"etc/redhat-release": {Data: []byte("AlmaLinux release 9.3 (Shamrock Pundit)\n")},
+	}, ReleaseFiles())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(release.ID, gc.Equals, "almalinux")
+	c.Check(release.VersionID, gc.Equals, "9.3")
+	c.Check(release.Source, gc.Equals, "/etc/redhat-release")
+}
+
+func (s *releaseFileSuite) TestReleaseFiles(c *gc.C) {
+	files := ReleaseFiles()
+	c.Assert(files[0], gc.Equals, ReleaseFile{Path: "/etc/os-release", Format: OSReleaseFormat})
+	c.Assert(files, gc.HasLen, 7)
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// SELinuxMode is the mode SELinux runs in.
+type SELinuxMode int
+
+const (
+	// SELinuxDisabled means SELinux is not enabled in the kernel.
+	SELinuxDisabled SELinuxMode = iota
+	// SELinuxPermissive means policy violations are logged but allowed.
+	SELinuxPermissive
+	// SELinuxEnforcing means policy violations are denied.
+	SELinuxEnforcing
+)
+
+var selinuxModeNames = map[SELinuxMode]string{
+	SELinuxDisabled:   "disabled",
+	SELinuxPermissive: "permissive",
+	SELinuxEnforcing:  "enforcing",
+}
+
+func (m SELinuxMode) String() string {
+	if name, ok := selinuxModeNames[m]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// LockdownMode is the kernel lockdown level.
+type LockdownMode int
+
+const (
+	// UnknownLockdown means the kernel does not report its lockdown
+	// level, as the lockdown security module is not enabled.
+	UnknownLockdown LockdownMode = iota
+	// LockdownNone means the kernel is not locked down.
+	LockdownNone
+	// LockdownIntegrity prevents userland from modifying the running
+	// kernel.
+	LockdownIntegrity
+	// LockdownConfidentiality also prevents userland from reading
+	// confidential material held by the kernel.
+	LockdownConfidentiality
+)
+
+var lockdownModeNames = map[LockdownMode]string{
+	UnknownLockdown:         "unknown",
+	LockdownNone:            "none",
+	LockdownIntegrity:       "integrity",
+	LockdownConfidentiality: "confidentiality",
+}
+
+func (m LockdownMode) String() string {
+	if name, ok := lockdownModeNames[m]; ok {
+		return name
+	}
+	return lockdownModeNames[UnknownLockdown]
+}
+
+// AppArmorProfile is a loaded AppArmor profile.
+type AppArmorProfile struct {
+	// Name is the name of the profile, usually the path of the program
+	// it confines.
+	Name string
+	// Mode is the mode the profile is loaded in, e.g. "enforce" or
+	// "complain".
+	Mode string
+}
+
+// AppArmorStatus describes the state of AppArmor.
+type AppArmorStatus struct {
+	// Enabled indicates that AppArmor is enabled in the kernel.
+	Enabled bool
+	// Profiles holds the loaded profiles, in the order the kernel reports
+	// them. It is empty if the profiles cannot be read, which requires
+	// root.
+	Profiles []AppArmorProfile
+}
+
+// SecurityStatus describes the security state of a machine.
+type SecurityStatus struct {
+	// FIPS indicates that the kernel runs in FIPS mode.
+	FIPS bool
+	// AppArmor is the state of AppArmor.
+	AppArmor AppArmorStatus
+	// SELinux is the mode of SELinux.
+	SELinux SELinuxMode
+	// Lockdown is the kernel lockdown level.
+	Lockdown LockdownMode
+	// UnprivilegedUserNamespaces indicates that processes without
+	// privileges may create user namespaces.
+	UnprivilegedUserNamespaces bool
+}
+
+// HostSecurityStatus returns the security state of the host, read
+// relative to HostRoot.
+func HostSecurityStatus() (SecurityStatus, error) {
+	return DetectSecurityStatus(HostFS())
+}
+
+// DetectSecurityStatus returns the security state described by the root
+// filesystem fsys, which is expected to include /proc and /sys.
+func DetectSecurityStatus(fsys fs.FS) (SecurityStatus, error) {
+	var status SecurityStatus
+
+	fips, _, err := readProbeFile(fsys, "/proc/sys/crypto/fips_enabled")
+	if err != nil {
+		return SecurityStatus{}, errors.Trace(err)
+	}
+	status.FIPS = strings.TrimSpace(string(fips)) == "1"
+
+	if status.AppArmor, err = detectAppArmor(fsys); err != nil {
+		return SecurityStatus{}, errors.Trace(err)
+	}
+
+	// selinuxfs is only mounted if SELinux is enabled.
+	if enforce, ok, err := readProbeFile(fsys, "/sys/fs/selinux/enforce"); err != nil {
+		return SecurityStatus{}, errors.Trace(err)
+	} else if ok {
+		status.SELinux = SELinuxPermissive
+		if strings.TrimSpace(string(enforce)) == "1" {
+			status.SELinux = SELinuxEnforcing
+		}
+	}
+
+	// The current level is bracketed, e.g. "none [integrity] confidentiality".
+	if lockdown, ok, err := readProbeFile(fsys, "/sys/kernel/security/lockdown"); err != nil {
+		return SecurityStatus{}, errors.Trace(err)
+	} else if ok {
+		for _, level := range strings.Fields(string(lockdown)) {
+			if !strings.HasPrefix(level, "[") || !strings.HasSuffix(level, "]") {
+				continue
+			}
+			for mode, name := range lockdownModeNames {
+				if name == strings.Trim(level, "[]") && mode != UnknownLockdown {
+					status.Lockdown = mode
+				}
+			}
+		}
+	}
+
+	if status.UnprivilegedUserNamespaces, err = unprivilegedUserNamespaces(fsys); err != nil {
+		return SecurityStatus{}, errors.Trace(err)
+	}
+	return status, nil
+}
+
+// detectAppArmor returns the state of AppArmor described by fsys.
+func detectAppArmor(fsys fs.FS) (AppArmorStatus, error) {
+	var status AppArmorStatus
+	enabled, _, err := readProbeFile(fsys, "/sys/module/apparmor/parameters/enabled")
+	if err != nil {
+		return AppArmorStatus{}, errors.Trace(err)
+	}
+	status.Enabled = strings.TrimSpace(string(enabled)) == "Y"
+	if !status.Enabled {
+		return status, nil
+	}
+
+	// Each line is a profile name followed by its mode in parentheses,
+	// e.g. "/usr/sbin/cupsd (enforce)".
+	profiles, _, err := readProbeFile(fsys, "/sys/kernel/security/apparmor/profiles")
+	if err != nil {
+		return AppArmorStatus{}, errors.Trace(err)
+	}
+	for _, line := range strings.Split(string(profiles), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		profile := AppArmorProfile{Name: line}
+		if i := strings.LastIndex(line, " ("); i != -1 && strings.HasSuffix(line, ")") {
+			profile.Name = line[:i]
+			profile.Mode = line[i+2 : len(line)-1]
+		}
+		status.Profiles = append(status.Profiles, profile)
+	}
+	return status, nil
+}
+
+// unprivilegedUserNamespaces reports whether fsys describes a kernel that
+// allows unprivileged processes to create user namespaces. Besides the
+// namespace limit, Debian derived kernels provide a switch for them, and
+// Ubuntu can restrict them to processes with an AppArmor profile that
+// allows them.
+func unprivilegedUserNamespaces(fsys fs.FS) (bool, error) {
+	limit, ok, err := readProbeFile(fsys, "/proc/sys/user/max_user_namespaces")
+	if err != nil {
+		return false, errors.Trace(err)
+	} else if !ok || strings.TrimSpace(string(limit)) == "0" {
+		return false, nil
+	}
+	for _, check := range []struct {
+		path       string
+		disallowed string
+	}{
+		{path: "/proc/sys/kernel/unprivileged_userns_clone", disallowed: "0"},
+		{path: "/proc/sys/kernel/apparmor_restrict_unprivileged_userns", disallowed: "1"},
+	} {
+		value, ok, err := readProbeFile(fsys, check.path)
+		if err != nil {
+			return false, errors.Trace(err)
+		} else if ok && strings.TrimSpace(string(value)) == check.disallowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
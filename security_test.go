@@ -0,0 +1,133 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2/internal/hosttest"
+)
+
+type securitySuite struct {
+}
+
+var _ = gc.Suite(&securitySuite{})
+
+const appArmorProfiles = `/usr/sbin/cupsd (enforce)
+/usr/lib/snapd/snap-confine (enforce)
+lsb_release (complain)
+unconfined-profile
+`
+
+var detectSecurityStatusTests = []struct {
+	message  string
+	files    fstest.MapFS
+	expected SecurityStatus
+}{{
+	message:  "nothing reported",
+	files:    fstest.MapFS{},
+	expected: SecurityStatus{},
+}, {
+	message: "ubuntu",
+	files: fstest.MapFS{
+		"proc/sys/crypto/fips_enabled":                          {Data: []byte("0\n")},
+		"sys/module/apparmor/parameters/enabled":                {Data: []byte("Y\n")},
+		"sys/kernel/security/apparmor/profiles":                 {Data: []byte(appArmorProfiles)},
+		"sys/kernel/security/lockdown":                          {Data: []byte("[none] integrity confidentiality\n")},
+		"proc/sys/user/max_user_namespaces":                     {Data: []byte("63483\n")},
+		"proc/sys/kernel/unprivileged_userns_clone":             {Data: []byte("1\n")},
+		"proc/sys/kernel/apparmor_restrict_unprivileged_userns": {Data: []byte("0\n")},
+	},
+	expected: SecurityStatus{
+		AppArmor: AppArmorStatus{
+			Enabled: true,
+			Profiles: []AppArmorProfile{
+				{Name: "/usr/sbin/cupsd", Mode: "enforce"},
+				{Name: "/usr/lib/snapd/snap-confine", Mode: "enforce"},
+				{Name: "lsb_release", Mode: "complain"},
+				{Name: "unconfined-profile"},
+			},
+		},
+		Lockdown:                   LockdownNone,
+		UnprivilegedUserNamespaces: true,
+	},
+}, {
+	message: "ubuntu restricting user namespaces",
+	files: fstest.MapFS{
+		"sys/module/apparmor/parameters/enabled":                {Data: []byte("Y\n")},
+		"proc/sys/user/max_user_namespaces":                     {Data: []byte("63483\n")},
+		"proc/sys/kernel/apparmor_restrict_unprivileged_userns": {Data: []byte("1\n")},
+	},
+	expected: SecurityStatus{
+		AppArmor: AppArmorStatus{Enabled: true},
+	},
+}, {
+	message: "rhel with fips",
+	files: fstest.MapFS{
+		"proc/sys/crypto/fips_enabled":      {Data: []byte("1\n")},
+		"sys/fs/selinux/enforce":            {Data: []byte("1")},
+		"sys/kernel/security/lockdown":      {Data: []byte("none [integrity] confidentiality\n")},
+		"proc/sys/user/max_user_namespaces": {Data: []byte("0\n")},
+	},
+	expected: SecurityStatus{
+		FIPS:     true,
+		SELinux:  SELinuxEnforcing,
+		Lockdown: LockdownIntegrity,
+	},
+}, {
+	message: "selinux permissive",
+	files: fstest.MapFS{
+		"sys/fs/selinux/enforce":            {Data: []byte("0")},
+		"sys/kernel/security/lockdown":      {Data: []byte("none integrity [confidentiality]\n")},
+		"proc/sys/user/max_user_namespaces": {Data: []byte("15000\n")},
+	},
+	expected: SecurityStatus{
+		SELinux:                    SELinuxPermissive,
+		Lockdown:                   LockdownConfidentiality,
+		UnprivilegedUserNamespaces: true,
+	},
+}, {
+	message: "debian disallowing user namespaces",
+	files: fstest.MapFS{
+		"sys/module/apparmor/parameters/enabled":    {Data: []byte("N\n")},
+		"proc/sys/user/max_user_namespaces":         {Data: []byte("63483\n")},
+		"proc/sys/kernel/unprivileged_userns_clone": {Data: []byte("0\n")},
+	},
+	expected: SecurityStatus{},
+}}
+
+func (s *securitySuite) TestDetectSecurityStatus(c *gc.C) {
+	for i, test := range detectSecurityStatusTests {
+		c.Logf("test %d: %s", i, test.message)
+		status, err := DetectSecurityStatus(test.files)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(status, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *securitySuite) TestHostSecurityStatusFollowsHostRoot(c *gc.C) {
+	root := c.MkDir()
+	hosttest.WriteFile(c, root, "proc/sys/crypto/fips_enabled", "1\n")
+	hosttest.WriteFile(c, root, "sys/kernel/security/lockdown", "none [integrity] confidentiality\n")
+	SetHostRoot(root)
+	defer SetHostRoot("")
+
+	status, err := HostSecurityStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, SecurityStatus{
+		FIPS:     true,
+		Lockdown: LockdownIntegrity,
+	})
+}
+
+func (s *securitySuite) TestStrings(c *gc.C) {
+	c.Check(SELinuxDisabled.String(), gc.Equals, "disabled")
+	c.Check(SELinuxEnforcing.String(), gc.Equals, "enforcing")
+	c.Check(SELinuxMode(99).String(), gc.Equals, "unknown")
+	c.Check(LockdownIntegrity.String(), gc.Equals, "integrity")
+	c.Check(LockdownMode(99).String(), gc.Equals, "unknown")
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os/v2"
+)
+
+// DetectSeries returns the series of the Linux root filesystem fsys, such
+// as a mounted machine image or chroot. The release is read from the first
+// usable file of os.ReleaseFiles, and Ubuntu versions that are not otherwise
+// known are resolved using the distro-info data found in fsys, or at
+// UbuntuDistroInfo if that has been set.
+func DetectSeries(fsys fs.FS) (string, error) {
+	release, err := jujuos.ReadReleaseFS(fsys, jujuos.ReleaseFiles())
+	if err != nil {
+		return "unknown", err
+	}
+	return seriesFromReleaseFS(fsys, release)
+}
+
+// seriesFromReleaseFS returns the series described by release, resolving
+// Ubuntu versions using the distro-info data described by DetectSeries.
+func seriesFromReleaseFS(fsys fs.FS, release *jujuos.OSRelease) (string, error) {
+	distroInfo := NewDistroInfoFS(fsys, UbuntuDistroInfo)
+	if UbuntuDistroInfo != defaultUbuntuDistroInfo {
+		// As for the host, a path that has been set explicitly names
+		// the file to read rather than one within fsys.
+		distroInfo = NewDistroInfo(UbuntuDistroInfo)
+	}
+	if err := distroInfo.Refresh(); err != nil {
+		logger.Warningf("failed to read distro info: %v", err)
+	}
+
+	seriesVersionsMutex.Lock()
+	defer seriesVersionsMutex.Unlock()
+	return seriesFromOSRelease(release, distroInfo)
+}
+
+// ubuntuCoreID is the os-release ID of Ubuntu Core.
+const ubuntuCoreID = "ubuntu-core"
+
+// seriesFromOSRelease returns the series of the distribution described by
+// release, which is resolved first so that the series agrees with the OS
+// type. A derivative of Ubuntu takes the series named by UBUNTU_CODENAME,
+// and one of CentOS or openSUSE that of its VERSION_ID. OS types without
+// series of their own, such as Debian or Rocky, have the genericlinux
+// series.
+func seriesFromOSRelease(release *jujuos.OSRelease, distroInfo *DistroInfo) (string, error) {
+	distro := jujuos.DistributionFromOSRelease(release)
+	derivative := distro.MatchedID != strings.ToLower(release.ID)
+	switch distro.OSType {
+	case jujuos.Ubuntu:
+		if derivative {
+			if _, ok := ubuntuSeries[release.UbuntuCodename]; ok {
+				return release.UbuntuCodename, nil
+			}
+			if _, ok := distroInfo.SeriesInfo(release.UbuntuCodename); ok {
+				return release.UbuntuCodename, nil
+			}
+			return "unknown", errors.Trace(unknownVersionSeriesError(release.UbuntuCodename))
+		}
+		versionID := release.VersionID
+		if distro.MatchedID == ubuntuCoreID && !strings.Contains(versionID, ".") {
+			// Ubuntu Core N is built on the Ubuntu N.04 base, whose
+			// series it takes.
+			versionID += ".04"
+		}
+		if series, ok := getValueFromSeriesVersion(ubuntuSeries, versionID); ok {
+			return series, nil
+		}
+		if s, ok := distroInfo.versionSeries(versionID); ok {
+			return s, nil
+		}
+	case jujuos.CentOS:
+		codename := "centos" + release.VersionID
+		if series, ok := getValue(centosSeries, codename); ok {
+			return series, nil
+		}
+	case jujuos.OpenSUSE:
+		codename := "opensuse" + strings.Split(release.VersionID, ".")[0]
+		if series, ok := getValue(opensuseSeries, codename); ok {
+			return series, nil
+		}
+	default:
+		if info, ok := distro.OSType.Info(); ok && info.Series != nil {
+			return info.Series.SeriesFromOSRelease(release)
+		}
+		return genericLinuxSeries, nil
+	}
+	return "unknown", errors.Trace(unknownVersionSeriesError(release.VersionID))
+}
+
+func getValue(from map[string]string, val string) (string, bool) {
+	for serie, ver := range from {
+		if ver == val {
+			return serie, true
+		}
+	}
+	return "", false
+}
+
+func getValueFromSeriesVersion(from map[string]SeriesVersionInfo, val string) (string, bool) {
+	for s, version := range from {
+		if version.Version == val {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"testing/fstest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujuos "github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type detectSeriesSuite struct {
+	testing.CleanupSuite
+}
+
+var _ = gc.Suite(&detectSeriesSuite{})
+
+func (s *detectSeriesSuite) TestDetectSeries(c *gc.C) {
+	for i, test := range []struct {
+		message string
+		fsys    fstest.MapFS
+		series  string
+	}{{
+		message: "ubuntu",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("ID=ubuntu\nVERSION_ID=\"22.04\"\n")},
+		},
+		series: "jammy",
+	}, {
+		message: "centos",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("ID=\"centos\"\nVERSION_ID=\"8\"\n")},
+		},
+		series: "centos8",
+	}, {
+		message: "lsb-release fallback",
+		fsys: fstest.MapFS{
+			"etc/lsb-release": {Data: []byte("DISTRIB_ID=Ubuntu\nDISTRIB_RELEASE=20.04\n")},
+		},
+		series: "focal",
+	}, {
+		message: "unknown ubuntu version resolved from distro-info",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("ID=ubuntu\nVERSION_ID=\"98.04\"\n")},
+			"usr/share/distro-info/ubuntu.csv": {Data: []byte(`version,codename,series,created,release,eol,eol-server
+12.04 LTS,Precise Pangolin,precise,2011-10-13,2012-04-26,2017-04-26
+98.04 LTS,Enterprise,kirk,2363-04-25,2363-10-17,2368-07-17
+`)},
+		},
+		series: "kirk",
+	}, {
+		message: "ubuntu core 18",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("NAME=\"Ubuntu Core\"\nID=ubuntu-core\nVERSION_ID=\"18\"\n")},
+		},
+		series: "bionic",
+	}, {
+		message: "ubuntu core 20",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("NAME=\"Ubuntu Core\"\nID=ubuntu-core\nVERSION_ID=\"20\"\n")},
+		},
+		series: "focal",
+	}, {
+		message: "ubuntu core 22",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("NAME=\"Ubuntu Core\"\nID=ubuntu-core\nVERSION_ID=\"22\"\n")},
+		},
+		series: "jammy",
+	}, {
+		message: "ubuntu core 24",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("NAME=\"Ubuntu Core\"\nID=ubuntu-core\nVERSION_ID=\"24\"\n")},
+		},
+		series: "noble",
+	}, {
+		message: "fedora coreos",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("ID=fedora\nVERSION_ID=39\nVARIANT_ID=coreos\n")},
+		},
+		series: "genericlinux",
+	}, {
+		message: "generic linux",
+		fsys: fstest.MapFS{
+			"etc/os-release": {Data: []byte("ID=arch\n")},
+		},
+		series: "genericlinux",
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		result, err := series.DetectSeries(test.fsys)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.series)
+	}
+
+	// Detecting the series of a filesystem does not change the series
+	// known to the package.
+	_, ok := series.UbuntuSupportedSeries()["kirk"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *detectSeriesSuite) TestDetectSeriesAgreesWithOS(c *gc.C) {
+	for i, test := range []struct {
+		message string
+		release string
+		os      jujuos.OSType
+		series  string
+	}{{
+		message: "ubuntu",
+		release: "ID=ubuntu\nVERSION_ID=\"22.04\"\nUBUNTU_CODENAME=jammy\n",
+		os:      jujuos.Ubuntu,
+		series:  "jammy",
+	}, {
+		message: "linux mint",
+		release: "ID=linuxmint\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=\"21.2\"\nUBUNTU_CODENAME=jammy\n",
+		os:      jujuos.Ubuntu,
+		series:  "jammy",
+	}, {
+		message: "pop",
+		release: "ID=pop\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=\"22.04\"\nUBUNTU_CODENAME=jammy\n",
+		os:      jujuos.Ubuntu,
+		series:  "jammy",
+	}, {
+		message: "ubuntu derivative without UBUNTU_CODENAME",
+		release: "ID=linuxmint\nID_LIKE=\"ubuntu debian\"\nVERSION_ID=\"21.2\"\n",
+		os:      jujuos.GenericLinux,
+		series:  "genericlinux",
+	}, {
+		message: "opensuse leap",
+		release: "ID=\"opensuse-leap\"\nID_LIKE=\"suse opensuse\"\nVERSION_ID=\"42.3\"\n",
+		os:      jujuos.OpenSUSE,
+		series:  "opensuseleap",
+	}, {
+		message: "capitalised centos",
+		release: "ID=CentOS\nVERSION_ID=\"7\"\n",
+		os:      jujuos.CentOS,
+		series:  "centos7",
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		fsys := fstest.MapFS{"etc/os-release": {Data: []byte(test.release)}}
+		osType, err := jujuos.DetectOS(fsys)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osType, gc.Equals, test.os)
+		s_, err := series.DetectSeries(fsys)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s_, gc.Equals, test.series)
+		seriesOS, err := series.GetOSFromSeries(s_)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(seriesOS, gc.Equals, osType)
+	}
+}
+
+func (s *detectSeriesSuite) TestDetectSeriesUnknownVersion(c *gc.C) {
+	result, err := series.DetectSeries(fstest.MapFS{
+		"etc/os-release": {Data: []byte("ID=ubuntu\nVERSION_ID=\"97.04\"\n")},
+	})
+	c.Assert(err, gc.ErrorMatches, `unknown series for version: "97.04"`)
+	c.Assert(err, jc.Satisfies, series.IsUnknownVersionSeriesError)
+	c.Assert(result, gc.Equals, "unknown")
+}
+
+func (s *detectSeriesSuite) TestDetectSeriesNoRelease(c *gc.C) {
+	result, err := series.DetectSeries(fstest.MapFS{})
+	c.Assert(err, jc.Satisfies, jujuos.IsReleaseNotFoundError)
+	c.Assert(result, gc.Equals, "unknown")
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// Detector detects the series of the filesystem described by an
+// os.Detector. The series is cached for as long as the os.Detector caches
+// the release data it was detected from. It is safe for concurrent use.
+type Detector struct {
+	os *os.Detector
+
+	mu sync.Mutex
+	// release is the release data the cached series and err were
+	// detected from, or nil if nothing is cached.
+	release *os.OSRelease
+	series  string
+	err     error
+}
+
+// NewDetector returns a Detector that describes the filesystem of
+// osDetector.
+func NewDetector(osDetector *os.Detector) *Detector {
+	return &Detector{os: osDetector}
+}
+
+// defaultDetector describes the host, and is used by HostSeries.
+var defaultDetector = NewDetector(os.DefaultDetector())
+
+// DefaultDetector returns the Detector that describes the host, which
+// HostSeries uses.
+func DefaultDetector() *Detector {
+	return defaultDetector
+}
+
+// Series returns the series of the filesystem. The errors are those
+// described by HostSeries.
+func (d *Detector) Series() (string, error) {
+	if osType, _ := d.os.OS(); osType == os.Kubernetes {
+		return kubernetesSeriesName, nil
+	}
+	// The os.Detector returns new release data whenever it reads the
+	// release files again, which invalidates the cached series.
+	release, releaseErr := d.os.Release()
+	d.mu.Lock()
+	if release != nil && release == d.release {
+		defer d.mu.Unlock()
+		return d.series, d.err
+	}
+	d.mu.Unlock()
+
+	series, err := detectSeries(d.os.FS(), release, releaseErr)
+	if err != nil {
+		err = errors.Annotate(err, "cannot determine host series")
+	}
+	if release != nil {
+		d.mu.Lock()
+		d.release, d.series, d.err = release, series, err
+		d.mu.Unlock()
+	}
+	return series, err
+}
+
+// Refresh discards every cached result of the detector and its
+// os.Detector.
+func (d *Detector) Refresh() {
+	d.os.Refresh()
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"testing/fstest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujuos "github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type detectorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&detectorSuite{})
+
+func (s *detectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// Keep the distro-info of the host out of the known series.
+	s.PatchEnvironment(jujuos.HostRootEnvVar, c.MkDir())
+
+	cleanup := series.SetSeriesVersions(make(map[string]string))
+	s.AddCleanup(func(*gc.C) { cleanup() })
+	s.AddCleanup(func(*gc.C) { jujuos.SetKubernetesDetection(false) })
+}
+
+func (s *detectorSuite) TestSeries(c *gc.C) {
+	fsys := fstest.MapFS{
+		"etc/os-release":                   {Data: []byte(futureReleaseFileContents)},
+		"usr/share/distro-info/ubuntu.csv": {Data: []byte(distroInfoContents)},
+	}
+	d := series.NewDetector(jujuos.NewDetector(jujuos.DetectorConfig{FS: fsys}))
+	s_, err := d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "spock")
+
+	// The series is cached until refreshed.
+	fsys["etc/os-release"].Data = []byte("ID=ubuntu\nVERSION_ID=12.04\n")
+	s_, err = d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "spock")
+
+	d.Refresh()
+	s_, err = d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "precise")
+}
+
+func (s *detectorSuite) TestSeriesExpires(c *gc.C) {
+	fsys := fstest.MapFS{
+		"etc/os-release":                   {Data: []byte(futureReleaseFileContents)},
+		"usr/share/distro-info/ubuntu.csv": {Data: []byte(distroInfoContents)},
+	}
+	clock := testclock.NewClock(time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC))
+	d := series.NewDetector(jujuos.NewDetector(jujuos.DetectorConfig{
+		FS:     fsys,
+		Clock:  clock,
+		MaxAge: time.Minute,
+	}))
+	s_, err := d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "spock")
+
+	// The series is detected again once the release data expires.
+	fsys["etc/os-release"].Data = []byte("ID=ubuntu\nVERSION_ID=12.04\n")
+	s_, err = d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "spock")
+
+	clock.Advance(time.Minute)
+	s_, err = d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "precise")
+}
+
+func (s *detectorSuite) TestSeriesError(c *gc.C) {
+	d := series.NewDetector(jujuos.NewDetector(jujuos.DetectorConfig{FS: fstest.MapFS{}}))
+	s_, err := d.Series()
+	c.Check(err, gc.ErrorMatches, "cannot determine host series: .*")
+	c.Check(s_, gc.Equals, "unknown")
+}
+
+func (s *detectorSuite) TestSeriesKubernetes(c *gc.C) {
+	d := series.NewDetector(jujuos.NewDetector(jujuos.DetectorConfig{FS: fstest.MapFS{
+		"etc/os-release": {Data: []byte(futureReleaseFileContents)},
+		"run/secrets/kubernetes.io/serviceaccount/token": {Data: []byte("token")},
+	}}))
+	jujuos.SetKubernetesDetection(true)
+	s_, err := d.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s_, gc.Equals, "kubernetes")
+}
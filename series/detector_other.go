@@ -0,0 +1,19 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build !linux
+// +build !linux
+
+package series
+
+import (
+	"io/fs"
+
+	"github.com/juju/os/v2"
+)
+
+// detectSeries returns the series of the machine the current process is
+// running on; only the Linux series can be read from a filesystem.
+func detectSeries(fs.FS, *os.OSRelease, error) (string, error) {
+	return readSeries()
+}
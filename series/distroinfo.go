@@ -5,7 +5,10 @@ package series
 
 import (
 	"encoding/csv"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -16,7 +19,12 @@ import (
 // UbuntuDistroInfo references a csv that contains all the distro information
 // about info. This includes what the names and versions of a distro and if the
 // distro is supported or not.
-var UbuntuDistroInfo = "/usr/share/distro-info/ubuntu.csv"
+//
+// The default path is read relative to the host root; a path set by the
+// caller is read as given.
+var UbuntuDistroInfo = defaultUbuntuDistroInfo
+
+const defaultUbuntuDistroInfo = "/usr/share/distro-info/ubuntu.csv"
 
 const dateFormat = "2006-01-02"
 
@@ -55,6 +63,7 @@ type DistroInfo struct {
 	path       string
 	info       map[string]DistroInfoSerie
 	fileSystem FileSystem
+	fsys       fs.FS
 }
 
 // NewDistroInfo creates a new DistroInfo for querying the distro.
@@ -66,17 +75,27 @@ func NewDistroInfo(path string) *DistroInfo {
 	}
 }
 
+// NewDistroInfoFS creates a new DistroInfo that reads the distro information
+// from fsys. The path is taken to be relative to the root of fsys.
+func NewDistroInfoFS(fsys fs.FS, path string) *DistroInfo {
+	return &DistroInfo{
+		path: path,
+		info: make(map[string]DistroInfoSerie),
+		fsys: fsys,
+	}
+}
+
 // Refresh will attempt to update the information it has about each distro and
 // if the distro is supported or not.
 func (d *DistroInfo) Refresh() error {
-	// On non-Ubuntu systems this file won't exist but that's expected.
-	if !d.fileSystem.Exists(d.path) {
-		return nil
-	}
-	f, err := d.fileSystem.Open(d.path)
+	f, err := d.open()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// On non-Ubuntu systems this file won't exist but that's expected.
+	if f == nil {
+		return nil
+	}
 	defer func() {
 		_ = f.Close()
 	}()
@@ -139,6 +158,28 @@ func (d *DistroInfo) Refresh() error {
 	return nil
 }
 
+// open opens the distro information, returning nil if it does not exist.
+func (d *DistroInfo) open() (io.ReadCloser, error) {
+	if d.fsys != nil {
+		name := strings.TrimPrefix(path.Clean("/"+d.path), "/")
+		f, err := d.fsys.Open(name)
+		if os.IsNotExist(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	if !d.fileSystem.Exists(d.path) {
+		return nil, nil
+	}
+	f, err := d.fileSystem.Open(d.path)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 // SeriesInfo returns the DistroInfoSerie for the series name.
 func (d *DistroInfo) SeriesInfo(seriesName string) (DistroInfoSerie, bool) {
 	d.mutex.RLock()
@@ -148,6 +189,20 @@ func (d *DistroInfo) SeriesInfo(seriesName string) (DistroInfoSerie, bool) {
 	return info, ok
 }
 
+// versionSeries returns the name of the series with the given numeric
+// version.
+func (d *DistroInfo) versionSeries(version string) (string, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for name, info := range d.info {
+		if strings.TrimSuffix(info.Version, " LTS") == version {
+			return name, true
+		}
+	}
+	return "", false
+}
+
 // record defines a raw distro line that hasn't been parsed.
 type record struct {
 	Version  string
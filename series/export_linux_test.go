@@ -6,7 +6,6 @@ package series
 var (
 	UbuntuDistroInfoPath = &UbuntuDistroInfo
 	ReadSeries           = readSeries
-	OSReleaseFile        = &osReleaseFile
 )
 
 // HideUbuntuSeries hides the global state of the ubuntu series for tests. The
@@ -0,0 +1,29 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// DefaultInitSystem returns the init system a machine running the given
+// series boots with by default, for use when the machine cannot be
+// inspected. It is the service manager of the series metadata. An error
+// satisfying errors.IsNotSupported is returned for series that do not use
+// a Linux init system.
+func DefaultInitSystem(series string) (os.InitSystemType, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return os.UnknownInitSystem, errors.Trace(err)
+	}
+	if !osType.IsLinux() {
+		return os.UnknownInitSystem, errors.NotSupportedf("init system for series %q", series)
+	}
+	metadata, err := SeriesMetadata(series)
+	if err != nil {
+		return os.UnknownInitSystem, errors.Trace(err)
+	}
+	return metadata.ServiceManager, nil
+}
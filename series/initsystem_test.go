@@ -0,0 +1,55 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type initSystemSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&initSystemSuite{})
+
+func (s *initSystemSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// Keep the distro-info of the host out of the known series.
+	s.PatchEnvironment(os.HostRootEnvVar, c.MkDir())
+}
+
+func (s *initSystemSuite) TestDefaultInitSystem(c *gc.C) {
+	for series_, expected := range map[string]os.InitSystemType{
+		"precise":      os.UpstartInit,
+		"trusty":       os.UpstartInit,
+		"vivid":        os.SystemdInit,
+		"xenial":       os.SystemdInit,
+		"jammy":        os.SystemdInit,
+		"centos7":      os.SystemdInit,
+		"opensuseleap": os.SystemdInit,
+		"genericlinux": os.SystemdInit,
+	} {
+		initType, err := series.DefaultInitSystem(series_)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(initType, gc.Equals, expected, gc.Commentf("%s", series_))
+	}
+}
+
+func (s *initSystemSuite) TestDefaultInitSystemNotSupported(c *gc.C) {
+	for _, series_ := range []string{"win2012r2", "mojave", "kubernetes"} {
+		_, err := series.DefaultInitSystem(series_)
+		c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	}
+}
+
+func (s *initSystemSuite) TestDefaultInitSystemUnknownSeries(c *gc.C) {
+	_, err := series.DefaultInitSystem("spock")
+	c.Check(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
+}
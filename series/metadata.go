@@ -0,0 +1,28 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/v2"
+)
+
+// SeriesMetadata returns the packaging and system conventions of the OS
+// release identified by series, such as the package manager of centos7.
+func SeriesMetadata(series string) (os.Metadata, error) {
+	osType, err := GetOSFromSeries(series)
+	if err != nil {
+		return os.Metadata{}, errors.Trace(err)
+	}
+	// Non-Ubuntu series versions may be prefixed by the OS name, as in
+	// centos7.
+	version, _ := SeriesVersion(series)
+	version = strings.TrimLeftFunc(version, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	return osType.MetadataForVersion(version), nil
+}
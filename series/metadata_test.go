@@ -0,0 +1,56 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package series_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/os/v2/series"
+)
+
+type metadataSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&metadataSuite{})
+
+func (s *metadataSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// Keep the distro-info of the host out of the known series.
+	s.PatchEnvironment(os.HostRootEnvVar, c.MkDir())
+}
+
+func (s *metadataSuite) TestSeriesMetadata(c *gc.C) {
+	for i, t := range []struct {
+		series          string
+		family          os.Family
+		packageFormat   os.PackageFormat
+		packageManagers []string
+		serviceManager  os.InitSystemType
+	}{
+		{"trusty", os.DebianFamily, os.DebPackageFormat, []string{"apt"}, os.UpstartInit},
+		{"jammy", os.DebianFamily, os.DebPackageFormat, []string{"apt"}, os.SystemdInit},
+		{"centos7", os.RedHatFamily, os.RPMPackageFormat, []string{"yum"}, os.SystemdInit},
+		{"centos9", os.RedHatFamily, os.RPMPackageFormat, []string{"dnf", "yum"}, os.SystemdInit},
+		{"opensuseleap", os.SUSEFamily, os.RPMPackageFormat, []string{"zypper"}, os.SystemdInit},
+		{"win2019", os.WindowsFamily, os.MSIPackageFormat, nil, os.WindowsServiceInit},
+		{"mojave", os.DarwinFamily, os.PKGPackageFormat, nil, os.LaunchdInit},
+	} {
+		c.Logf("test %d: %s", i, t.series)
+		m, err := series.SeriesMetadata(t.series)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.Family, gc.Equals, t.family)
+		c.Check(m.PackageFormat, gc.Equals, t.packageFormat)
+		c.Check(m.PackageManagers, jc.DeepEquals, t.packageManagers)
+		c.Check(m.ServiceManager, gc.Equals, t.serviceManager)
+	}
+}
+
+func (s *metadataSuite) TestSeriesMetadataUnknownSeries(c *gc.C) {
+	_, err := series.SeriesMetadata("spock")
+	c.Check(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
+}
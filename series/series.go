@@ -8,7 +8,6 @@ package series
 import (
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -18,21 +17,23 @@ import (
 const (
 	genericLinuxSeries  = "genericlinux"
 	genericLinuxVersion = "genericlinux"
+
+	kubernetesSeriesName = "kubernetes"
 )
 
 var (
 	// HostSeries returns the series of the machine the current process is
-	// running on (overrideable var for testing).
+	// running on (overrideable var for testing). On Linux, errors caused by
+	// missing or incomplete release files satisfy os.IsReleaseNotFoundError
+	// and os.IsMissingIDError respectively. On every OS, errors caused by a
+	// release whose series is not known satisfy IsUnknownVersionSeriesError.
+	// In a Kubernetes pod the series is "kubernetes" if enabled by
+	// os.SetKubernetesDetection.
 	HostSeries func() (string, error) = hostSeries
 
 	// MustHostSeries calls HostSeries and panics if there is an error.
 	MustHostSeries = mustHostSeries
 
-	seriesOnce sync.Once
-	// These are filled in by the first call to hostSeries
-	series    string
-	seriesErr error
-
 	// timeNow is time.Now, but overrideable via TimeNow in tests.
 	timeNow = time.Now
 )
@@ -40,14 +41,7 @@ var (
 // hostSeries returns the series of the machine the current process is
 // running on.
 func hostSeries() (string, error) {
-	var err error
-	seriesOnce.Do(func() {
-		series, err = readSeries()
-		if err != nil {
-			seriesErr = errors.Annotate(err, "cannot determine host series")
-		}
-	})
-	return series, seriesErr
+	return defaultDetector.Series()
 }
 
 // mustHostSeries calls HostSeries and panics if there is an error.
@@ -124,7 +118,7 @@ var macOSXSeries = map[int]string{
 func macOSXSeriesFromMajorVersion(majorVersion int) (string, error) {
 	series, ok := macOSXSeries[majorVersion]
 	if !ok {
-		return "unknown", errors.Errorf("unknown series version %d", majorVersion)
+		return "unknown", errors.Trace(unknownVersionSeriesError(strconv.Itoa(majorVersion)))
 	}
 	return series, nil
 }
@@ -4,7 +4,7 @@
 package series
 
 import (
-	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 
@@ -12,65 +12,31 @@ import (
 	jujuos "github.com/juju/os/v2"
 )
 
-var (
-	// osReleaseFile is the name of the file that is read in order to determine
-	// the linux type release version.
-	osReleaseFile = "/etc/os-release"
-)
-
 func readSeries() (string, error) {
-	values, err := jujuos.ReadOSRelease(osReleaseFile)
-	if err != nil {
-		return "unknown", err
-	}
 	updateSeriesVersionsOnce()
-	return seriesFromOSRelease(values)
-}
-
-func seriesFromOSRelease(values map[string]string) (string, error) {
-	switch values["ID"] {
-	case strings.ToLower(jujuos.Ubuntu.String()):
-		return getValueFromSeriesVersion(ubuntuSeries, values["VERSION_ID"])
-	case strings.ToLower(jujuos.CentOS.String()):
-		codename := fmt.Sprintf("%s%s", values["ID"], values["VERSION_ID"])
-		return getValue(centosSeries, codename)
-	case strings.ToLower(jujuos.OpenSUSE.String()):
-		codename := fmt.Sprintf("%s%s",
-			values["ID"],
-			strings.Split(values["VERSION_ID"], ".")[0])
-		return getValue(opensuseSeries, codename)
-	default:
-		return genericLinuxSeries, nil
-	}
-}
-
-func getValue(from map[string]string, val string) (string, error) {
-	for serie, ver := range from {
-		if ver == val {
-			return serie, nil
-		}
-	}
-	return "unknown", errors.New("could not determine series")
+	return DetectSeries(jujuos.HostFS())
 }
 
-func getValueFromSeriesVersion(from map[string]SeriesVersionInfo, val string) (string, error) {
-	for s, version := range from {
-		if version.Version == val {
-			return s, nil
-		}
+// detectSeries returns the series of fsys from its release data, or the
+// error encountered reading it.
+func detectSeries(fsys fs.FS, release *jujuos.OSRelease, releaseErr error) (string, error) {
+	if releaseErr != nil {
+		return "unknown", releaseErr
 	}
-	return "unknown", errors.New("could not determine series")
+	updateSeriesVersionsOnce()
+	return seriesFromReleaseFS(fsys, release)
 }
 
 // ReleaseVersion looks for the value of VERSION_ID in the content of
-// the os-release.  If the value is not found, the file is not found, or
-// an error occurs reading the file, an empty string is returned.
+// the os-release, or the first fallback release file if os-release is
+// absent. If the value is not found, no file is found, or an error occurs
+// reading the file, an empty string is returned.
 func ReleaseVersion() string {
-	release, err := jujuos.ReadOSRelease(osReleaseFile)
+	release, err := jujuos.ReadReleaseFS(jujuos.HostFS(), jujuos.ReleaseFiles())
 	if err != nil {
 		return ""
 	}
-	return release["VERSION_ID"]
+	return release.VersionID
 }
 
 // LocalSeriesVersionInfo returns the local series versions and OS type.
@@ -123,14 +89,25 @@ func updateLocalSeriesVersions() error {
 	return nil
 }
 
-// defaultFileSystem implements the FileSystem for the DistroInfo.
+// defaultFileSystem implements the FileSystem for the DistroInfo. The
+// default distro-info path is relative to the host root.
 type defaultFileSystem struct{}
 
 func (defaultFileSystem) Open(path string) (*os.File, error) {
-	return os.Open(path)
+	return os.Open(distroInfoHostPath(path))
 }
 
 func (defaultFileSystem) Exists(path string) bool {
-	_, err := os.Stat(path)
+	_, err := os.Stat(distroInfoHostPath(path))
 	return !os.IsNotExist(err)
 }
+
+// distroInfoHostPath returns the location of the distro-info file at path.
+// Only the built in default is rebased under the host root, as a path that
+// has been set explicitly already names the file to read.
+func distroInfoHostPath(path string) string {
+	if path == defaultUbuntuDistroInfo {
+		return jujuos.HostPath(path)
+	}
+	return path
+}
@@ -4,6 +4,7 @@
 package series_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
@@ -11,6 +12,8 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	jujuos "github.com/juju/os/v2"
+	"github.com/juju/os/v2/internal/hosttest"
 	"github.com/juju/os/v2/series"
 )
 
@@ -43,16 +46,11 @@ func (s *linuxVersionSuite) SetUpTest(c *gc.C) {
 func (s *linuxVersionSuite) TestOSVersion(c *gc.C) {
 	// Set up fake /etc/os-release file from the future.
 	d := c.MkDir()
-	release := filepath.Join(d, "future-release")
-	s.PatchValue(series.OSReleaseFile, release)
-	err := ioutil.WriteFile(release, []byte(futureReleaseFileContents), 0666)
-	c.Assert(err, jc.ErrorIsNil)
+	hosttest.WriteFile(c, d, "etc/os-release", futureReleaseFileContents)
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
 
 	// Set up fake /usr/share/distro-info/ubuntu.csv, also from the future.
-	distroInfo := filepath.Join(d, "ubuntu.csv")
-	err = ioutil.WriteFile(distroInfo, []byte(distroInfoContents), 0644)
-	c.Assert(err, jc.ErrorIsNil)
-	s.PatchValue(series.UbuntuDistroInfoPath, distroInfo)
+	hosttest.WriteFile(c, d, "usr/share/distro-info/ubuntu.csv", distroInfoContents)
 
 	// Ensure the future series can be read even though Juju doesn't
 	// know about it.
@@ -138,11 +136,10 @@ VERSION_ID="9.10"
 		expected: "9.10",
 	}} {
 		c.Logf("%v: %v", i, test.message)
-		filename := filepath.Join(c.MkDir(), "os-release")
-		s.PatchValue(series.OSReleaseFile, filename)
+		d := c.MkDir()
+		s.PatchEnvironment(jujuos.HostRootEnvVar, d)
 		if test.releaseContent != "" {
-			err := ioutil.WriteFile(filename, []byte(test.releaseContent+"\n"), 0644)
-			c.Assert(err, jc.ErrorIsNil)
+			hosttest.WriteFile(c, d, "etc/os-release", test.releaseContent+"\n")
 		}
 		value := series.ReleaseVersion()
 		c.Assert(value, gc.Equals, test.expected)
@@ -244,8 +241,8 @@ BUG_REPORT_URL="https://bugzilla.redhat.com/"
 ID="SuSE"
 VERSION_ID="12"
 `,
-	"genericlinux",
-	"",
+	"unknown",
+	`unknown series for version: "12"`,
 }, {
 
 	"",
@@ -256,24 +253,30 @@ VERSION_ID="12"
 ID="centos"
 `,
 	"unknown",
-	"could not determine series",
+	`unknown series for version: ""`,
 }, {
 	`NAME=openSUSE
 ID=opensuse
 VERSION_ID="42.3"`,
 	"opensuseleap",
 	"",
+}, {
+	`NAME="Kali GNU/Linux"
+ID=kali
+ID_LIKE=debian
+VERSION_ID="2024.1"`,
+	"kali2024",
+	"",
 },
 }
 
 func (s *readSeriesSuite) TestReadSeries(c *gc.C) {
+	registerKali(c, &s.CleanupSuite)
 	d := c.MkDir()
-	f := filepath.Join(d, "foo")
-	s.PatchValue(series.OSReleaseFile, f)
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
 	for i, t := range readSeriesTests {
 		c.Logf("test %d", i)
-		err := ioutil.WriteFile(f, []byte(t.contents), 0666)
-		c.Assert(err, jc.ErrorIsNil)
+		hosttest.WriteFile(c, d, "etc/os-release", t.contents)
 		series, err := series.ReadSeries()
 		if t.err == "" {
 			c.Assert(err, jc.ErrorIsNil)
@@ -284,3 +287,214 @@ func (s *readSeriesSuite) TestReadSeries(c *gc.C) {
 		c.Assert(series, gc.Equals, t.series)
 	}
 }
+
+func (s *readSeriesSuite) TestReadSeriesWithoutSeriesOfOwn(c *gc.C) {
+	d := c.MkDir()
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	for i, t := range []struct {
+		id     string
+		osType jujuos.OSType
+	}{
+		{"debian", jujuos.Debian},
+		{"fedora", jujuos.Fedora},
+		{"rhel", jujuos.RHEL},
+		{"rocky", jujuos.Rocky},
+		{"almalinux", jujuos.AlmaLinux},
+		{"amzn", jujuos.AmazonLinux},
+		{"alpine", jujuos.Alpine},
+		{"arch", jujuos.Arch},
+		{"sles", jujuos.SLES},
+	} {
+		c.Logf("test %d: %s", i, t.id)
+		hosttest.WriteFile(c, d, "etc/os-release", fmt.Sprintf("ID=%s\nVERSION_ID=\"9\"\n", t.id))
+		osType, err := jujuos.DetectOS(jujuos.HostFS())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(osType, gc.Equals, t.osType)
+
+		// These OS types deliberately have the genericlinux series, as
+		// they have no series of their own.
+		s_, err := series.ReadSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s_, gc.Equals, "genericlinux")
+		c.Check(series.OSSupportedSeries(t.osType), gc.HasLen, 0)
+	}
+}
+
+var readSeriesFallbackTests = []struct {
+	message  string
+	filename string
+	contents string
+	series   string
+	version  string
+}{{
+	message:  "usr lib os-release",
+	filename: "usr/lib/os-release",
+	contents: "ID=ubuntu\nVERSION_ID=\"20.04\"\n",
+	series:   "focal",
+	version:  "20.04",
+}, {
+	message:  "lsb-release",
+	filename: "etc/lsb-release",
+	contents: `DISTRIB_ID=Ubuntu
+DISTRIB_RELEASE=22.04
+DISTRIB_CODENAME=jammy
+DISTRIB_DESCRIPTION="Ubuntu 22.04.3 LTS"
+`,
+	series:  "jammy",
+	version: "22.04",
+}, {
+	message:  "redhat-release",
+	filename: "etc/redhat-release",
+	contents: "CentOS Linux release 7.9.2009 (Core)\n",
+	series:   "centos7",
+	version:  "7",
+}, {
+	message:  "centos-release",
+	filename: "etc/centos-release",
+	contents: "CentOS Stream release 9\n",
+	series:   "centos9",
+	version:  "9",
+}, {
+	message:  "SuSE-release",
+	filename: "etc/SuSE-release",
+	contents: "openSUSE 42.3 (x86_64)\nVERSION = 42.3\nCODENAME = Malachite\n",
+	series:   "opensuseleap",
+	version:  "42.3",
+}, {
+	message:  "debian_version",
+	filename: "etc/debian_version",
+	contents: "12.4\n",
+	series:   "genericlinux",
+	version:  "12",
+}}
+
+func (s *readSeriesSuite) TestReadSeriesFallback(c *gc.C) {
+	for i, t := range readSeriesFallbackTests {
+		c.Logf("test %d: %s", i, t.message)
+		d := c.MkDir()
+		hosttest.WriteFile(c, d, t.filename, t.contents)
+		s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+		result, err := series.ReadSeries()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, t.series)
+		c.Check(series.ReleaseVersion(), gc.Equals, t.version)
+	}
+}
+
+func (s *readSeriesSuite) TestReadSeriesNoReleaseFiles(c *gc.C) {
+	s.PatchEnvironment(jujuos.HostRootEnvVar, c.MkDir())
+	result, err := series.ReadSeries()
+	c.Assert(err, gc.ErrorMatches, `release file not found \(tried /etc/os-release, /usr/lib/os-release, .*\)`)
+	c.Assert(err, jc.Satisfies, jujuos.IsReleaseNotFoundError)
+	c.Check(result, gc.Equals, "unknown")
+}
+
+func (s *readSeriesSuite) TestReadSeriesMissingID(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "NAME=Linux\n")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	_, err := series.ReadSeries()
+	c.Assert(err, jc.Satisfies, jujuos.IsMissingIDError)
+}
+
+func (s *readSeriesSuite) TestReadSeriesUnknownVersion(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=centos\nVERSION_ID=\"5\"\n")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	result, err := series.ReadSeries()
+	c.Check(err, gc.ErrorMatches, `unknown series for version: "5"`)
+	c.Check(err, jc.Satisfies, series.IsUnknownVersionSeriesError)
+	c.Check(result, gc.Equals, "unknown")
+}
+
+func (s *readSeriesSuite) TestHostSeriesFollowsHostRoot(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=ubuntu\nVERSION_ID=\"22.04\"\n")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	result, err := series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "jammy")
+
+	s.PatchEnvironment(jujuos.HostRootEnvVar, c.MkDir())
+	_, err = series.HostSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot determine host series: release file not found .*")
+}
+
+func (s *readSeriesSuite) TestHostSeriesErrors(c *gc.C) {
+	d := c.MkDir()
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	_, err := series.HostSeries()
+	c.Check(err, jc.Satisfies, jujuos.IsReleaseNotFoundError)
+
+	hosttest.WriteFile(c, d, "etc/os-release", "NAME=Linux\n")
+	series.DefaultDetector().Refresh()
+	_, err = series.HostSeries()
+	c.Check(err, jc.Satisfies, jujuos.IsMissingIDError)
+
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=centos\nVERSION_ID=\"5\"\n")
+	series.DefaultDetector().Refresh()
+	result, err := series.HostSeries()
+	c.Check(err, gc.ErrorMatches, `cannot determine host series: unknown series for version: "5"`)
+	c.Check(err, jc.Satisfies, series.IsUnknownVersionSeriesError)
+	c.Check(result, gc.Equals, "unknown")
+}
+
+func (s *readSeriesSuite) TestHostSeriesKubernetes(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", "ID=ubuntu\nVERSION_ID=\"22.04\"\n")
+	hosttest.WriteFile(c, d, "var/run/secrets/kubernetes.io/serviceaccount/token", "token")
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	result, err := series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "jammy")
+
+	jujuos.SetKubernetesDetection(true)
+	s.AddCleanup(func(*gc.C) { jujuos.SetKubernetesDetection(false) })
+	result, err = series.HostSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "kubernetes")
+}
+
+func (s *linuxVersionSuite) TestDistroInfoPathFollowsHostRoot(c *gc.C) {
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "usr/share/distro-info/ubuntu.csv", distroInfoContents)
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	info := series.NewDistroInfo(series.UbuntuDistroInfo)
+	c.Assert(info.Refresh(), jc.ErrorIsNil)
+	_, ok := info.SeriesInfo("spock")
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *linuxVersionSuite) TestExplicitDistroInfoPathIgnoresHostRoot(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distroInfoContents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvironment(jujuos.HostRootEnvVar, c.MkDir())
+
+	info := series.NewDistroInfo(filename)
+	c.Assert(info.Refresh(), jc.ErrorIsNil)
+	_, ok := info.SeriesInfo("spock")
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *linuxVersionSuite) TestDetectSeriesExplicitDistroInfoPathIgnoresHostRoot(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "ubuntu.csv")
+	err := ioutil.WriteFile(filename, []byte(distroInfoContents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(series.UbuntuDistroInfoPath, filename)
+	d := c.MkDir()
+	hosttest.WriteFile(c, d, "etc/os-release", futureReleaseFileContents)
+	s.PatchEnvironment(jujuos.HostRootEnvVar, d)
+
+	result, err := series.DetectSeries(jujuos.HostFS())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, "spock")
+}
@@ -91,17 +91,18 @@ func (*kernelVersionSuite) TestMacOSXSeries(c *gc.C) {
 		{version: 15, series: "elcapitan"},
 		{version: 16, series: "sierra"},
 		{version: 18, series: "mojave"},
-		{version: 4, series: "unknown", err: `unknown series version 4`},
-		{version: 0, series: "unknown", err: `unknown series version 0`},
+		{version: 4, series: "unknown", err: `unknown series for version: "4"`},
+		{version: 0, series: "unknown", err: `unknown series for version: "0"`},
 	}
 	for _, test := range tests {
-		series, err := series.MacOSXSeriesFromMajorVersion(test.version)
+		result, err := series.MacOSXSeriesFromMajorVersion(test.version)
 		if test.err != "" {
 			c.Assert(err, gc.ErrorMatches, test.err)
+			c.Assert(err, jc.Satisfies, series.IsUnknownVersionSeriesError)
 		} else {
 			c.Assert(err, jc.ErrorIsNil)
 		}
-		c.Check(series, gc.Equals, test.series)
+		c.Check(result, gc.Equals, test.series)
 	}
 }
 
@@ -59,7 +59,7 @@ func readSeries() (string, error) {
 			}
 		}
 	}
-	return "unknown", errors.Errorf("unknown series %q", ver)
+	return "unknown", errors.Trace(unknownVersionSeriesError(ver))
 }
 
 func isWindowsNano() (bool, error) {
@@ -466,6 +466,13 @@ func getOSFromSeries(series string) (os.OSType, error) {
 			return os.OSX, nil
 		}
 	}
+	for _, osType := range os.RegisteredOSTypes() {
+		for _, val := range registeredSeries(osType) {
+			if val == series {
+				return osType, nil
+			}
+		}
+	}
 
 	return os.Unknown, errors.Trace(unknownOSForSeriesError(series))
 }
@@ -794,17 +801,28 @@ func ESMSupportedJujuSeries() []string {
 }
 
 // OSSupportedSeries returns the series of the specified OS on which we
-// can run Juju workloads.
-func OSSupportedSeries(os os.OSType) []string {
+// can run Juju workloads. OS types without series of their own, such as
+// Debian or Rocky, have none: their hosts have the genericlinux series of
+// GenericLinux.
+func OSSupportedSeries(osType os.OSType) []string {
 	var osSeries []string
 	for _, series := range SupportedSeries() {
 		seriesOS, err := GetOSFromSeries(series)
-		if err != nil || seriesOS != os {
+		if err != nil || seriesOS != osType {
 			continue
 		}
 		osSeries = append(osSeries, series)
 	}
-	return osSeries
+	return append(osSeries, registeredSeries(osType)...)
+}
+
+// registeredSeries returns the series resolved for an OS type added with
+// os.RegisterOSType.
+func registeredSeries(osType os.OSType) []string {
+	if info, ok := osType.Info(); ok && info.Series != nil {
+		return info.Series.Series()
+	}
+	return nil
 }
 
 // UpdateSeriesVersions forces an update of the series versions by querying
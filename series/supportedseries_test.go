@@ -4,6 +4,7 @@
 package series_test
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/testing"
@@ -165,6 +166,55 @@ func (s *supportedSeriesSuite) TestOSSupportedSeries(c *gc.C) {
 	c.Assert(supported, jc.SameContents, []string{"genericlinux"})
 }
 
+// testResolver resolves the series of the OS type registered by these
+// tests.
+type testResolver struct{}
+
+func (testResolver) SeriesFromOSRelease(release *os.OSRelease) (string, error) {
+	return "kali" + strings.Split(release.VersionID, ".")[0], nil
+}
+
+func (testResolver) Series() []string {
+	return []string{"kali2023", "kali2024"}
+}
+
+// registerKali registers an OS type resolved by testResolver for the
+// duration of the test.
+func registerKali(c *gc.C, s *testing.CleanupSuite) os.OSType {
+	t, err := os.RegisterOSType(os.OSTypeInfo{
+		Name:   "Kali",
+		IDs:    []string{"kali"},
+		Linux:  true,
+		Family: os.DebianFamily,
+		Series: testResolver{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(os.UnregisterOSType(t), jc.ErrorIsNil)
+	})
+	return t
+}
+
+func (s *supportedSeriesSuite) TestGetOSFromSeriesRegistered(c *gc.C) {
+	kaliOSType := registerKali(c, &s.CleanupSuite)
+	got, err := series.GetOSFromSeries("kali2024")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, kaliOSType)
+	c.Assert(got.String(), gc.Equals, "Kali")
+
+	_, err = series.GetOSFromSeries("kali1999")
+	c.Assert(err, jc.Satisfies, series.IsUnknownOSForSeriesError)
+}
+
+func (s *supportedSeriesSuite) TestOSSupportedSeriesRegistered(c *gc.C) {
+	kaliOSType := registerKali(c, &s.CleanupSuite)
+	setSeriesTestData()
+	supported := series.OSSupportedSeries(kaliOSType)
+	c.Assert(supported, jc.SameContents, []string{"kali2023", "kali2024"})
+	supported = series.OSSupportedSeries(os.Ubuntu)
+	c.Assert(supported, jc.SameContents, []string{"trusty", "utopic"})
+}
+
 func (s *supportedSeriesSuite) TestVersionSeriesValid(c *gc.C) {
 	setSeriesTestData()
 	seriesResult, err := series.VersionSeries("14.04")
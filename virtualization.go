@@ -0,0 +1,165 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Hypervisor identifies the hypervisor a machine runs under.
+type Hypervisor int
+
+const (
+	// BareMetal means no hypervisor was detected.
+	BareMetal Hypervisor = iota
+	// UnknownHypervisor is a hypervisor that is not recognised, such as
+	// one announced only by the hypervisor CPU flag.
+	UnknownHypervisor
+	// KVMHypervisor is KVM or QEMU.
+	KVMHypervisor
+	VMwareHypervisor
+	HyperVHypervisor
+	XenHypervisor
+	VirtualBoxHypervisor
+	FirecrackerHypervisor
+)
+
+var hypervisorNames = map[Hypervisor]string{
+	BareMetal:             "none",
+	UnknownHypervisor:     "unknown",
+	KVMHypervisor:         "kvm",
+	VMwareHypervisor:      "vmware",
+	HyperVHypervisor:      "hyperv",
+	XenHypervisor:         "xen",
+	VirtualBoxHypervisor:  "virtualbox",
+	FirecrackerHypervisor: "firecracker",
+}
+
+func (h Hypervisor) String() string {
+	if name, ok := hypervisorNames[h]; ok {
+		return name
+	}
+	return hypervisorNames[UnknownHypervisor]
+}
+
+// Virtualization describes the hypervisor a machine runs under.
+type Virtualization struct {
+	// Hypervisor is the detected hypervisor, or BareMetal.
+	Hypervisor Hypervisor
+	// Evidence holds every observation that indicates a hypervisor, in
+	// the order they were made.
+	Evidence []Evidence
+}
+
+// IsVirtual reports whether a hypervisor was detected.
+func (v Virtualization) IsVirtual() bool {
+	return v.Hypervisor != BareMetal
+}
+
+// HostVirtualization returns the hypervisor of the host, read relative to
+// HostRoot.
+func HostVirtualization() (Virtualization, error) {
+	return DetectVirtualization(HostFS())
+}
+
+// hypervisorDMIRules match the DMI strings set by each hypervisor.
+var hypervisorDMIRules = []dmiRule{
+	{match: map[string]string{"sys_vendor": "QEMU"}, indicates: KVMHypervisor},
+	{match: map[string]string{"product_name": "KVM"}, indicates: KVMHypervisor},
+	{match: map[string]string{"product_name": "Google Compute Engine"}, indicates: KVMHypervisor},
+	// The Nitro hypervisor of EC2 is based on KVM.
+	{match: map[string]string{"sys_vendor": "Amazon EC2"}, indicates: KVMHypervisor},
+	{match: map[string]string{"bios_vendor": "Amazon EC2"}, indicates: KVMHypervisor},
+	{match: map[string]string{"sys_vendor": "VMware"}, indicates: VMwareHypervisor},
+	{match: map[string]string{"product_name": "VMware"}, indicates: VMwareHypervisor},
+	{match: map[string]string{
+		"sys_vendor":   "Microsoft Corporation",
+		"product_name": "Virtual Machine",
+	}, indicates: HyperVHypervisor},
+	{match: map[string]string{"sys_vendor": "Xen"}, indicates: XenHypervisor},
+	{match: map[string]string{"bios_vendor": "Xen"}, indicates: XenHypervisor},
+	{match: map[string]string{"sys_vendor": "innotek"}, indicates: VirtualBoxHypervisor},
+	{match: map[string]string{"product_name": "VirtualBox"}, indicates: VirtualBoxHypervisor},
+	{match: map[string]string{"sys_vendor": "Firecracker"}, indicates: FirecrackerHypervisor},
+}
+
+// DetectVirtualization returns the hypervisor described by the root
+// filesystem fsys, which is expected to include /proc and /sys. The
+// hypervisor is that of the first evidence that identifies one; if the
+// only evidence is the hypervisor CPU flag it is UnknownHypervisor.
+func DetectVirtualization(fsys fs.FS) (Virtualization, error) {
+	var result Virtualization
+	add := func(hypervisor Hypervisor, path, detail string) {
+		result.Evidence = append(result.Evidence, Evidence{
+			Indicates: hypervisor,
+			Path:      path,
+			Detail:    detail,
+		})
+	}
+
+	if contents, ok, err := readProbeFile(fsys, "/sys/hypervisor/type"); err != nil {
+		return Virtualization{}, errors.Trace(err)
+	} else if ok {
+		if name := strings.TrimSpace(string(contents)); name == "xen" {
+			add(XenHypervisor, "/sys/hypervisor/type", name)
+		} else if name != "" {
+			add(UnknownHypervisor, "/sys/hypervisor/type", name)
+		}
+	}
+
+	dmi, err := readDMI(fsys)
+	if err != nil {
+		return Virtualization{}, errors.Trace(err)
+	}
+	result.Evidence = append(result.Evidence, matchDMI(dmi, hypervisorDMIRules)...)
+
+	hypervisorFlag := false
+	if contents, ok, err := readProbeFile(fsys, "/proc/cpuinfo"); err != nil {
+		return Virtualization{}, errors.Trace(err)
+	} else if ok {
+		hypervisorFlag = cpuinfoHasFlag(string(contents), "hypervisor")
+		if hypervisorFlag {
+			add(UnknownHypervisor, "/proc/cpuinfo", "hypervisor flag")
+		}
+	}
+
+	// Firecracker provides neither DMI nor a PCI bus, so its virtio
+	// devices are declared on the kernel command line.
+	if contents, ok, err := readProbeFile(fsys, "/proc/cmdline"); err != nil {
+		return Virtualization{}, errors.Trace(err)
+	} else if ok && hypervisorFlag && len(dmi) == 0 && strings.Contains(string(contents), "virtio_mmio.device=") {
+		add(FirecrackerHypervisor, "/proc/cmdline", "virtio_mmio.device")
+	}
+
+	for _, e := range result.Evidence {
+		if hypervisor, ok := e.Indicates.(Hypervisor); ok && hypervisor != UnknownHypervisor {
+			result.Hypervisor = hypervisor
+			return result, nil
+		}
+	}
+	if len(result.Evidence) > 0 {
+		result.Hypervisor = UnknownHypervisor
+	}
+	return result, nil
+}
+
+// cpuinfoHasFlag reports whether any processor in the contents of
+// /proc/cpuinfo has the given flag.
+func cpuinfoHasFlag(cpuinfo, flag string) bool {
+	for _, line := range strings.Split(cpuinfo, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) != "flags" {
+			continue
+		}
+		for _, f := range strings.Fields(parts[1]) {
+			if f == flag {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package os
+
+import (
+	"testing/fstest"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type virtualizationSuite struct {
+}
+
+var _ = gc.Suite(&virtualizationSuite{})
+
+const (
+	bareMetalCPUInfo = `processor	: 0
+vendor_id	: GenuineIntel
+flags		: fpu vme de pse tsc msr pae mce cx8 apic sep mtrr
+`
+	virtualCPUInfo = `processor	: 0
+vendor_id	: GenuineIntel
+flags		: fpu vme de pse tsc msr pae mce cx8 apic sep mtrr hypervisor
+`
+)
+
+var detectVirtualizationTests = []struct {
+	message    string
+	files      fstest.MapFS
+	hypervisor Hypervisor
+}{{
+	message: "bare metal",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Dell Inc.\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("PowerEdge R640\n")},
+		"proc/cpuinfo":                  {Data: []byte(bareMetalCPUInfo)},
+	},
+	hypervisor: BareMetal,
+}, {
+	message: "surface laptop",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Microsoft Corporation\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Surface Laptop 5\n")},
+		"proc/cpuinfo":                  {Data: []byte(bareMetalCPUInfo)},
+	},
+	hypervisor: BareMetal,
+}, {
+	message: "qemu",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("QEMU\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Standard PC (Q35 + ICH9, 2009)\n")},
+		"proc/cpuinfo":                  {Data: []byte(virtualCPUInfo)},
+	},
+	hypervisor: KVMHypervisor,
+}, {
+	message: "gce",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Google\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Google Compute Engine\n")},
+	},
+	hypervisor: KVMHypervisor,
+}, {
+	message: "aws nitro",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Amazon EC2\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("m5.large\n")},
+		"sys/class/dmi/id/bios_vendor":  {Data: []byte("Amazon EC2\n")},
+	},
+	hypervisor: KVMHypervisor,
+}, {
+	message: "vmware",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("VMware, Inc.\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("VMware Virtual Platform\n")},
+	},
+	hypervisor: VMwareHypervisor,
+}, {
+	message: "hyper-v",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Microsoft Corporation\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Virtual Machine\n")},
+		"proc/cpuinfo":                  {Data: []byte(virtualCPUInfo)},
+	},
+	hypervisor: HyperVHypervisor,
+}, {
+	message: "xen",
+	files: fstest.MapFS{
+		"sys/hypervisor/type":          {Data: []byte("xen\n")},
+		"sys/class/dmi/id/bios_vendor": {Data: []byte("Xen\n")},
+	},
+	hypervisor: XenHypervisor,
+}, {
+	message: "virtualbox",
+	files: fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("innotek GmbH\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("VirtualBox\n")},
+	},
+	hypervisor: VirtualBoxHypervisor,
+}, {
+	message: "firecracker",
+	files: fstest.MapFS{
+		"proc/cpuinfo": {Data: []byte(virtualCPUInfo)},
+		"proc/cmdline": {Data: []byte("console=ttyS0 reboot=k panic=1 virtio_mmio.device=4K@0xd0000000:5\n")},
+	},
+	hypervisor: FirecrackerHypervisor,
+}, {
+	message: "unknown hypervisor",
+	files: fstest.MapFS{
+		"proc/cpuinfo": {Data: []byte(virtualCPUInfo)},
+	},
+	hypervisor: UnknownHypervisor,
+}, {
+	message:    "nothing readable",
+	files:      fstest.MapFS{},
+	hypervisor: BareMetal,
+}}
+
+func (s *virtualizationSuite) TestDetectVirtualization(c *gc.C) {
+	for i, t := range detectVirtualizationTests {
+		c.Logf("test %d: %s", i, t.message)
+		result, err := DetectVirtualization(t.files)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Hypervisor, gc.Equals, t.hypervisor)
+		c.Check(result.IsVirtual(), gc.Equals, t.hypervisor != BareMetal)
+	}
+}
+
+func (s *virtualizationSuite) TestDetectVirtualizationEvidence(c *gc.C) {
+	result, err := DetectVirtualization(fstest.MapFS{
+		"sys/class/dmi/id/sys_vendor":   {Data: []byte("Microsoft Corporation\n")},
+		"sys/class/dmi/id/product_name": {Data: []byte("Virtual Machine\n")},
+		"proc/cpuinfo":                  {Data: []byte(virtualCPUInfo)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Evidence, jc.DeepEquals, []Evidence{{
+		Indicates: HyperVHypervisor,
+		Path:      "/sys/class/dmi/id/sys_vendor",
+		Detail:    "Microsoft Corporation",
+	}, {
+		Indicates: HyperVHypervisor,
+		Path:      "/sys/class/dmi/id/product_name",
+		Detail:    "Virtual Machine",
+	}, {
+		Indicates: UnknownHypervisor,
+		Path:      "/proc/cpuinfo",
+		Detail:    "hypervisor flag",
+	}})
+}
+
+func (s *virtualizationSuite) TestHypervisorString(c *gc.C) {
+	c.Check(BareMetal.String(), gc.Equals, "none")
+	c.Check(KVMHypervisor.String(), gc.Equals, "kvm")
+	c.Check(HyperVHypervisor.String(), gc.Equals, "hyperv")
+	c.Check(Hypervisor(99).String(), gc.Equals, "unknown")
+}